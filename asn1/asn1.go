@@ -8,12 +8,26 @@ import (
 
 type Linter struct {
 	e errors.Errors
+
+	// offset of the TLV that is passed to CheckFormat
+	offset int
+
+	// namedBits is set while walking the value of an extension that contains
+	// a named bit list
+	namedBits bool
+}
+
+// Extensions that are encoded as a BIT STRING with a named bit list, for
+// these values DER requires that trailing zero bits are removed.
+var namedBitLists = []asn1.ObjectIdentifier{
+	{2, 5, 29, 15},                // keyUsage
+	{2, 16, 840, 1, 113730, 1, 1}, // netscape-cert-type
 }
 
 // CheckStruct returns a list of errors based on strict checks on the raw ASN1
 // encoding of the input der.
 func (l *Linter) CheckStruct(der []byte) *errors.Errors {
-	l.walk(der)
+	l.walk(der, 0)
 	if l.e.IsError() {
 		return &l.e
 	}
@@ -22,27 +36,85 @@ func (l *Linter) CheckStruct(der []byte) *errors.Errors {
 
 // walk is a recursive call that walks over the ASN1 structured data until no
 // remaining bytes are left. For each non compound it will call the ASN1 format
-// checker.
-func (l *Linter) walk(der []byte) {
-	var err error
-	var d asn1.RawValue
+// checker, offset is the position of der in the complete structure.
+func (l *Linter) walk(der []byte, offset int) {
+	var oid asn1.ObjectIdentifier
 
 	for len(der) > 0 {
-		der, err = asn1.Unmarshal(der, &d)
+		h, err := parseHeader(der)
 		if err != nil {
-			// Errors should be included in the report, but allow format checking when
-			// data has been decoded.
-			l.e.Err(err.Error())
-			if len(d.Bytes) == 0 {
-				return
-			}
+			l.e.Err("%s at offset %d", err.Error(), offset)
+			return
+		}
+		if h.nonMinimalTag {
+			l.e.Err("Non-minimal tag number encoding at offset %d", offset)
+		}
+		if h.nonMinimalLength {
+			l.e.Err("Non-minimal length encoding at offset %d", offset)
+		}
+
+		// Without a length the end of the value can't be determined
+		if h.length < 0 {
+			l.e.Err("Indefinite length encoding is not allowed in DER at offset %d", offset)
+			return
+		}
+		if h.size+h.length > len(der) {
+			l.e.Err("Data truncated at offset %d", offset)
+			return
+		}
+
+		d := asn1.RawValue{
+			Class:      h.class,
+			Tag:        h.tag,
+			IsCompound: h.compound,
+			Bytes:      der[h.size : h.size+h.length],
+			FullBytes:  der[:h.size+h.length],
 		}
 
 		// A compound is an ASN.1 container that contains other structs.
 		if d.IsCompound {
-			l.walk(d.Bytes)
+			l.walk(d.Bytes, offset+h.size)
 		} else {
+			l.offset = offset
 			l.CheckFormat(d)
 		}
+
+		// The extnValue of an extension follows the extnID, walk the values
+		// that contain a DER encoded value.
+		if d.Class == asn1.ClassUniversal {
+			switch d.Tag {
+			case asn1.TagOID:
+				oid = nil
+				asn1.Unmarshal(d.FullBytes, &oid)
+			case asn1.TagOctetString:
+				if oid != nil && isDER(d.Bytes) {
+					l.namedBits = isNamedBitList(oid)
+					l.walk(d.Bytes, offset+h.size)
+					l.namedBits = false
+				}
+				oid = nil
+			}
+		}
+
+		der = der[h.size+h.length:]
+		offset += h.size + h.length
+	}
+}
+
+// isDER returns true if b contains a single ASN.1 value, the contents of an
+// OCTET STRING that is not DER encoded are not walked.
+func isDER(b []byte) bool {
+	var v asn1.RawValue
+	rest, err := asn1.Unmarshal(b, &v)
+	return err == nil && len(rest) == 0
+}
+
+// isNamedBitList returns true if the extension contains a named bit list
+func isNamedBitList(oid asn1.ObjectIdentifier) bool {
+	for _, n := range namedBitLists {
+		if n.Equal(oid) {
+			return true
+		}
 	}
+	return false
 }
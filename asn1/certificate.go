@@ -0,0 +1,132 @@
+package asn1
+
+import (
+	"encoding/asn1"
+)
+
+// Certificate is the schema of an X.509 certificate as defined in the ASN.1
+// module of RFC 5280 Appendix A.1.
+//
+//  Certificate  ::=  SEQUENCE  {
+//       tbsCertificate       TBSCertificate,
+//       signatureAlgorithm   AlgorithmIdentifier,
+//       signatureValue       BIT STRING  }
+//
+var Certificate = sequence("Certificate",
+	sequence("tbsCertificate",
+		optional(explicit(0, withDefault(primitive("version", asn1.TagInteger), []byte{0}))),
+		primitive("serialNumber", asn1.TagInteger),
+		algorithmIdentifier("signature"),
+		name("issuer"),
+		sequence("validity",
+			choice("notBefore", primitive("utcTime", asn1.TagUTCTime), primitive("generalTime", asn1.TagGeneralizedTime)),
+			choice("notAfter", primitive("utcTime", asn1.TagUTCTime), primitive("generalTime", asn1.TagGeneralizedTime)),
+		),
+		name("subject"),
+		sequence("subjectPublicKeyInfo",
+			algorithmIdentifier("algorithm"),
+			primitive("subjectPublicKey", asn1.TagBitString),
+		),
+		optional(implicit(1, primitive("issuerUniqueID", asn1.TagBitString))),
+		optional(implicit(2, primitive("subjectUniqueID", asn1.TagBitString))),
+		optional(explicit(3, sequenceOf("extensions", 1,
+			sequence("extension",
+				primitive("extnID", asn1.TagOID),
+				optional(withDefault(primitive("critical", asn1.TagBoolean), []byte{0x00})),
+				primitive("extnValue", asn1.TagOctetString),
+			),
+		))),
+	),
+	algorithmIdentifier("signatureAlgorithm"),
+	primitive("signatureValue", asn1.TagBitString),
+)
+
+// algorithmIdentifier returns the schema of an AlgorithmIdentifier
+//
+//  AlgorithmIdentifier  ::=  SEQUENCE  {
+//       algorithm               OBJECT IDENTIFIER,
+//       parameters              ANY DEFINED BY algorithm OPTIONAL  }
+//
+func algorithmIdentifier(n string) *Schema {
+	return sequence(n,
+		primitive("algorithm", asn1.TagOID),
+		optional(anyValue("parameters")),
+	)
+}
+
+// name returns the schema of a Name, the only alternative of the CHOICE is
+// the RDNSequence.
+//
+//  Name ::= CHOICE { rdnSequence  RDNSequence }
+//  RDNSequence ::= SEQUENCE OF RelativeDistinguishedName
+//  RelativeDistinguishedName ::= SET SIZE (1..MAX) OF AttributeTypeAndValue
+//
+func name(n string) *Schema {
+	return sequenceOf(n, 0,
+		setOf("rdn", 1,
+			sequence("attributeTypeAndValue",
+				primitive("type", asn1.TagOID),
+				anyValue("value"),
+			),
+		),
+	)
+}
+
+// primitive returns the schema of a universal type with the primitive
+// encoding
+func primitive(n string, tag int) *Schema {
+	return &Schema{name: n, class: asn1.ClassUniversal, tag: tag}
+}
+
+// anyValue returns the schema of an ANY type
+func anyValue(n string) *Schema {
+	return &Schema{name: n, any: true}
+}
+
+// sequence returns the schema of a SEQUENCE with the given components
+func sequence(n string, fields ...*Schema) *Schema {
+	return &Schema{name: n, class: asn1.ClassUniversal, tag: asn1.TagSequence, compound: true, fields: fields}
+}
+
+// sequenceOf returns the schema of a SEQUENCE OF with a minimum size
+func sequenceOf(n string, min int, of *Schema) *Schema {
+	return &Schema{name: n, class: asn1.ClassUniversal, tag: asn1.TagSequence, compound: true, of: of, min: min}
+}
+
+// setOf returns the schema of a SET OF with a minimum size
+func setOf(n string, min int, of *Schema) *Schema {
+	return &Schema{name: n, class: asn1.ClassUniversal, tag: asn1.TagSet, compound: true, of: of, min: min}
+}
+
+// choice returns the schema of a CHOICE between the alternatives
+func choice(n string, alternatives ...*Schema) *Schema {
+	return &Schema{name: n, choice: alternatives}
+}
+
+// explicit returns the schema of an explicitly tagged type, the name of the
+// type is used for the tagged value.
+func explicit(tag int, s *Schema) *Schema {
+	return &Schema{name: s.name, class: asn1.ClassContextSpecific, tag: tag, compound: true, explicit: s}
+}
+
+// implicit returns the schema of an implicitly tagged type
+func implicit(tag int, s *Schema) *Schema {
+	t := *s
+	t.class = asn1.ClassContextSpecific
+	t.tag = tag
+	t.implicit = true
+	return &t
+}
+
+// optional marks a component of a SEQUENCE as OPTIONAL
+func optional(s *Schema) *Schema {
+	s.optional = true
+	return s
+}
+
+// withDefault marks a component of a SEQUENCE as DEFAULT, def contains the
+// content octets of the DER encoded default value.
+func withDefault(s *Schema, def []byte) *Schema {
+	s.def = def
+	return s
+}
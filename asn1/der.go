@@ -0,0 +1,185 @@
+package asn1
+
+import (
+	"fmt"
+)
+
+// header contains the decoded identifier and length octets of a TLV, the
+// length is -1 when the indefinite form is used.
+type header struct {
+	class    int
+	tag      int
+	compound bool
+	length   int
+	size     int
+
+	// encodings that are valid BER but not DER
+	nonMinimalTag    bool
+	nonMinimalLength bool
+}
+
+// parseHeader decodes the identifier and length octets of the TLV at the start
+// of der, errors are only returned when the header can't be decoded at all.
+func parseHeader(der []byte) (header, error) {
+	var h header
+	if len(der) < 2 {
+		return h, fmt.Errorf("Data truncated")
+	}
+
+	b := der[0]
+	h.class = int(b >> 6)
+	h.compound = b&0x20 == 0x20
+	h.tag = int(b & 0x1f)
+	h.size = 1
+
+	// X.690 8.1.2.4: high-tag-number form
+	if h.tag == 0x1f {
+		h.tag = 0
+		for i := 0; ; i++ {
+			if h.size >= len(der) {
+				return h, fmt.Errorf("Data truncated")
+			}
+			if i > 3 {
+				return h, fmt.Errorf("Tag number too large")
+			}
+			b = der[h.size]
+			h.size++
+			if i == 0 && b == 0x80 {
+				h.nonMinimalTag = true
+			}
+			h.tag = h.tag<<7 | int(b&0x7f)
+			if b&0x80 == 0 {
+				break
+			}
+		}
+		if h.tag < 0x1f {
+			h.nonMinimalTag = true
+		}
+	}
+
+	if h.size >= len(der) {
+		return h, fmt.Errorf("Data truncated")
+	}
+	b = der[h.size]
+	h.size++
+
+	// X.690 8.1.3.4: short form
+	if b&0x80 == 0 {
+		h.length = int(b)
+		return h, nil
+	}
+
+	// X.690 10.1: the definite form of length encoding shall be used
+	n := int(b & 0x7f)
+	if n == 0 {
+		h.length = -1
+		return h, nil
+	}
+	if n == 0x7f {
+		return h, fmt.Errorf("Reserved length encoding")
+	}
+	if n > 4 {
+		return h, fmt.Errorf("Length too large")
+	}
+	if h.size+n > len(der) {
+		return h, fmt.Errorf("Data truncated")
+	}
+
+	for i := 0; i < n; i++ {
+		h.length = h.length<<8 | int(der[h.size+i])
+	}
+	h.size += n
+
+	// X.690 10.1: the length shall be encoded in the minimum number of octets
+	if der[h.size-n] == 0 || h.length < 0x80 {
+		h.nonMinimalLength = true
+	}
+
+	return h, nil
+}
+
+// checkBoolean verifies the DER encoding of a BOOLEAN (X.690 11.1)
+func (l *Linter) checkBoolean(b []byte) {
+	if len(b) != 1 {
+		l.e.Err("BOOLEAN with invalid length %d at offset %d", len(b), l.offset)
+		return
+	}
+	if b[0] != 0x00 && b[0] != 0xff {
+		l.e.Err("BOOLEAN value 0x%02x is not 0x00 or 0xFF at offset %d", b[0], l.offset)
+	}
+}
+
+// checkInteger verifies that an INTEGER or ENUMERATED is encoded in the
+// minimum number of octets (X.690 8.3.2)
+func (l *Linter) checkInteger(name string, b []byte) {
+	if len(b) == 0 {
+		l.e.Err("%s without content at offset %d", name, l.offset)
+		return
+	}
+	if len(b) > 1 && (b[0] == 0x00 && b[1]&0x80 == 0 || b[0] == 0xff && b[1]&0x80 == 0x80) {
+		l.e.Err("%s is not minimally encoded at offset %d", name, l.offset)
+	}
+}
+
+// checkBitString verifies the unused bits of a BIT STRING, trailing zero bits
+// are only checked when the BIT STRING contains a named bit list.
+func (l *Linter) checkBitString(b []byte) {
+	if len(b) == 0 {
+		l.e.Err("BIT STRING without unused bits octet at offset %d", l.offset)
+		return
+	}
+
+	// X.690 8.6.2.2
+	unused := b[0]
+	if unused > 7 {
+		l.e.Err("BIT STRING with %d unused bits at offset %d", unused, l.offset)
+		return
+	}
+	if len(b) == 1 {
+		if unused != 0 {
+			l.e.Err("Empty BIT STRING with %d unused bits at offset %d", unused, l.offset)
+		}
+		return
+	}
+
+	// X.690 11.2.1: each unused bit shall be set to zero
+	last := b[len(b)-1]
+	if last&(1<<unused-1) != 0 {
+		l.e.Err("BIT STRING unused bits are not zero at offset %d", l.offset)
+		return
+	}
+
+	// X.690 11.2.2: trailing zero bits shall be removed from a named bit list
+	if l.namedBits && last&(1<<unused) == 0 {
+		l.e.Err("BIT STRING named bit list contains trailing zero bits at offset %d", l.offset)
+	}
+}
+
+// checkObjectIdentifier verifies that each arc of an OBJECT IDENTIFIER or
+// RELATIVE-OID is encoded in the minimum number of octets (X.690 8.19.2)
+func (l *Linter) checkObjectIdentifier(name string, b []byte) {
+	if len(b) == 0 {
+		l.e.Err("%s without content at offset %d", name, l.offset)
+		return
+	}
+	if b[len(b)-1]&0x80 == 0x80 {
+		l.e.Err("%s is truncated at offset %d", name, l.offset)
+		return
+	}
+
+	start := true
+	for _, c := range b {
+		if start && c == 0x80 {
+			l.e.Err("%s arc is not minimally encoded at offset %d", name, l.offset)
+			return
+		}
+		start = c&0x80 == 0
+	}
+}
+
+// checkNull verifies that a NULL has no content (X.690 8.8.2)
+func (l *Linter) checkNull(b []byte) {
+	if len(b) != 0 {
+		l.e.Err("NULL with content at offset %d", l.offset)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
@@ -16,22 +17,27 @@ var formatGeneralizedTime = regexp.MustCompile("^([0-9]{4})([01][0-9])([0-3][0-9
 
 // CheckFormat returns a list of formatting errors based on the expected ASN1
 // encoding according to the class and tag of the raw value.
-// TODO: Create checks for remaining class 0 tags
 // TODO: Should we create extensions for other classes, even include class 0?
 func (l *Linter) CheckFormat(d asn1.RawValue) {
 	if d.Class == 0 {
 		switch d.Tag {
 		case 0: // "reserved for BER"
 		case 1: // "BOOLEAN"
+			l.checkBoolean(d.Bytes)
 		case 2: // "INTEGER"
+			l.checkInteger("INTEGER", d.Bytes)
 		case 3: // "BIT STRING"
+			l.checkBitString(d.Bytes)
 		case 4: // "OCTET STRING"
 		case 5: // "NULL"
+			l.checkNull(d.Bytes)
 		case 6: // "OBJECT IDENTIFIER"
+			l.checkObjectIdentifier("OBJECT IDENTIFIER", d.Bytes)
 		case 7: // "ObjectDescriptor"
 		case 8: // "INSTANCE OF, EXTERNAL"
 		case 9: // "REAL"
 		case 10: // "ENUMERATED"
+			l.checkInteger("ENUMERATED", d.Bytes)
 		case 11: // "EMBEDDED PDV"
 		case 12: // "UTF8String"
 			if !utf8.Valid(d.Bytes) {
@@ -44,6 +50,7 @@ func (l *Linter) CheckFormat(d asn1.RawValue) {
 				l.e.Err("Control character in UTF8String '%s'", string(d.Bytes))
 			}
 		case 13: // "RELATIVE-OID"
+			l.checkObjectIdentifier("RELATIVE-OID", d.Bytes)
 		case 16: // "SEQUENCE, SEQUENCE OF"
 		case 17: // "SET, SET OF"
 		case 18: // "NumericString"
@@ -102,11 +109,8 @@ func (l *Linter) CheckFormat(d asn1.RawValue) {
 			if !bytes.HasSuffix(d.Bytes, []byte{90}) {
 				l.e.Err("Generalized Time not in Zulu/GMT")
 			}
-			// TODO: Can we use binary.BigEndian.Varint(d.Bytes[0:3]), for better performance?
-			if v.Year() < 2050 {
-				l.e.Err("Generalized Time before 2050")
-			}
-
+			// The UTCTime/GeneralizedTime switch in 2050 depends on the field,
+			// see CheckTime.
 			if !formatGeneralizedTime.Match(d.Bytes) {
 				l.e.Err("Invalid Generalized Time")
 			}
@@ -129,26 +133,55 @@ func (l *Linter) CheckFormat(d asn1.RawValue) {
 				l.e.Err("Control character in GeneralString '%s'", string(d.Bytes))
 			}
 		case 28: // "UniversalString"
-			l.e.Warning("Using deprecated UniversalString for '%s'", string(d.Bytes))
-			if isForbiddenString(d.Bytes) {
-				l.e.Err("Forbidden value in UniversalString '%s'", string(d.Bytes))
+			if len(d.Bytes)%4 != 0 {
+				l.e.Err("UniversalString length is not a multiple of 4 at offset %d", l.offset)
+				return
 			}
-			if isControlCharacter(d.Bytes) {
-				l.e.Err("Control character in UniversalString '%s'", string(d.Bytes))
+			v := decodeUniversalString(d.Bytes)
+			l.e.Warning("Using deprecated UniversalString for '%s'", string(v))
+			if isForbiddenString(v) {
+				l.e.Err("Forbidden value in UniversalString '%s'", string(v))
+			}
+			if isControlCharacter(v) {
+				l.e.Err("Control character in UniversalString '%s'", string(v))
 			}
 		case 29: // "CHARACTER STRING"
 		case 30: // "BMPString"
-			l.e.Warning("Using deprecated BMPString for '%s'", string(d.Bytes))
-			if isForbiddenString(d.Bytes) {
-				l.e.Err("Forbidden value in BMPString '%s'", string(d.Bytes))
+			if len(d.Bytes)%2 != 0 {
+				l.e.Err("BMPString length is not a multiple of 2 at offset %d", l.offset)
+				return
 			}
-			if isControlCharacter(d.Bytes) {
-				l.e.Err("Control character in BMPString '%s'", string(d.Bytes))
+			v := decodeBMPString(d.Bytes)
+			l.e.Warning("Using deprecated BMPString for '%s'", string(v))
+			if isForbiddenString(v) {
+				l.e.Err("Forbidden value in BMPString '%s'", string(v))
+			}
+			if isControlCharacter(v) {
+				l.e.Err("Control character in BMPString '%s'", string(v))
 			}
 		}
 	}
 }
 
+// decodeBMPString returns the UTF-8 encoding of a big endian UCS-2 string
+func decodeBMPString(b []byte) []byte {
+	s := make([]uint16, len(b)/2)
+	for i := range s {
+		s[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return []byte(string(utf16.Decode(s)))
+}
+
+// decodeUniversalString returns the UTF-8 encoding of a big endian UCS-4
+// string
+func decodeUniversalString(b []byte) []byte {
+	var s []rune
+	for ; len(b) >= 4; b = b[4:] {
+		s = append(s, rune(b[0])<<24|rune(b[1])<<16|rune(b[2])<<8|rune(b[3]))
+	}
+	return []byte(string(s))
+}
+
 // Version of isPrintable without allowing a *
 // Source: https://golang.org/src/encoding/asn1/asn1.go
 func isPrintable(b byte) bool {
@@ -2,6 +2,8 @@ package asn1
 
 import (
 	"testing"
+
+	"github.com/globalsign/certlint/errors"
 )
 
 func TestIsForbiddenString(t *testing.T) {
@@ -23,3 +25,137 @@ func TestIsForbiddenString(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckStruct(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		DER            []byte
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: BOOLEAN, INTEGER and NULL",
+			DER:  []byte{0x30, 0x09, 0x01, 0x01, 0xff, 0x02, 0x02, 0x00, 0x80, 0x05, 0x00},
+		},
+		{
+			Name: "Invalid: BOOLEAN value",
+			DER:  []byte{0x30, 0x03, 0x01, 0x01, 0x01},
+			ExpectedErrors: []string{
+				"BOOLEAN value 0x01 is not 0x00 or 0xFF at offset 2",
+			},
+		},
+		{
+			Name: "Invalid: INTEGER leading octets",
+			DER:  []byte{0x30, 0x08, 0x02, 0x02, 0x00, 0x7f, 0x02, 0x02, 0xff, 0x80},
+			ExpectedErrors: []string{
+				"INTEGER is not minimally encoded at offset 2",
+				"INTEGER is not minimally encoded at offset 6",
+			},
+		},
+		{
+			Name: "Invalid: NULL with content",
+			DER:  []byte{0x05, 0x01, 0x00},
+			ExpectedErrors: []string{
+				"NULL with content at offset 0",
+			},
+		},
+		{
+			Name: "Invalid: OBJECT IDENTIFIER arcs",
+			DER:  []byte{0x06, 0x03, 0x2a, 0x80, 0x01, 0x06, 0x02, 0x2a, 0x86},
+			ExpectedErrors: []string{
+				"OBJECT IDENTIFIER arc is not minimally encoded at offset 0",
+				"OBJECT IDENTIFIER is truncated at offset 5",
+			},
+		},
+		{
+			Name: "Invalid: BIT STRING unused bits",
+			DER:  []byte{0x03, 0x02, 0x08, 0x00, 0x03, 0x02, 0x01, 0x01, 0x03, 0x01, 0x02},
+			ExpectedErrors: []string{
+				"BIT STRING with 8 unused bits at offset 0",
+				"BIT STRING unused bits are not zero at offset 4",
+				"Empty BIT STRING with 2 unused bits at offset 8",
+			},
+		},
+		{
+			Name: "Valid: trailing zero bits outside a named bit list",
+			DER:  []byte{0x03, 0x02, 0x04, 0xa0},
+		},
+		{
+			Name: "Valid: keyUsage",
+			DER:  []byte{0x30, 0x0e, 0x06, 0x03, 0x55, 0x1d, 0x0f, 0x01, 0x01, 0xff, 0x04, 0x04, 0x03, 0x02, 0x05, 0xa0},
+		},
+		{
+			Name: "Invalid: keyUsage with trailing zero bits",
+			DER:  []byte{0x30, 0x0e, 0x06, 0x03, 0x55, 0x1d, 0x0f, 0x01, 0x01, 0xff, 0x04, 0x04, 0x03, 0x02, 0x04, 0xa0},
+			ExpectedErrors: []string{
+				"BIT STRING named bit list contains trailing zero bits at offset 12",
+			},
+		},
+		{
+			Name: "Invalid: BOOLEAN in basicConstraints",
+			DER:  []byte{0x30, 0x0c, 0x06, 0x03, 0x55, 0x1d, 0x13, 0x04, 0x05, 0x30, 0x03, 0x01, 0x01, 0x01},
+			ExpectedErrors: []string{
+				"BOOLEAN value 0x01 is not 0x00 or 0xFF at offset 11",
+			},
+		},
+		{
+			Name: "Valid: extension value that is not DER encoded",
+			DER:  []byte{0x30, 0x09, 0x06, 0x02, 0x2a, 0x03, 0x04, 0x03, 0x01, 0x02, 0x03},
+		},
+		{
+			Name: "Warning: BMPString",
+			DER:  []byte{0x1e, 0x04, 0x00, 'O', 0x00, 'K'},
+			ExpectedErrors: []string{
+				"Using deprecated BMPString for 'OK'",
+			},
+		},
+		{
+			Name: "Invalid: BMPString control character",
+			DER:  []byte{0x1e, 0x04, 0x00, 'O', 0x00, 0x07},
+			ExpectedErrors: []string{
+				"Using deprecated BMPString for 'O\a'",
+				"Control character in BMPString 'O\a'",
+			},
+		},
+		{
+			Name: "Invalid: non-minimal length",
+			DER:  []byte{0x30, 0x06, 0x04, 0x81, 0x01, 0x00, 0x05, 0x00},
+			ExpectedErrors: []string{
+				"Non-minimal length encoding at offset 2",
+			},
+		},
+		{
+			Name: "Invalid: indefinite length",
+			DER:  []byte{0x30, 0x80, 0x05, 0x00, 0x00, 0x00},
+			ExpectedErrors: []string{
+				"Indefinite length encoding is not allowed in DER at offset 0",
+			},
+		},
+		{
+			Name: "Invalid: truncated",
+			DER:  []byte{0x30, 0x05, 0x05, 0x00},
+			ExpectedErrors: []string{
+				"Data truncated at offset 0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var errList []errors.Err
+			l := new(Linter)
+			if e := l.CheckStruct(tc.DER); e != nil {
+				errList = e.List()
+			}
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of CheckStruct errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
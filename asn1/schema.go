@@ -0,0 +1,305 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"strconv"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// Schema describes a type of an ASN.1 module, it is used to verify the
+// structure of an encoding and to locate the encoding of a specific field.
+type Schema struct {
+	name     string
+	class    int
+	tag      int
+	compound bool
+	optional bool
+
+	// DER content octets of the DEFAULT value
+	def []byte
+
+	// Explicitly tagged types contain the encoding of the inner type,
+	// implicitly tagged types replace the tag of the underlying type.
+	explicit *Schema
+	implicit bool
+
+	any    bool
+	choice []*Schema
+	fields []*Schema
+	of     *Schema
+	min    int
+}
+
+// Field contains the encoding of a field and the path of the field in the
+// schema, e.g. tbsCertificate.validity.notAfter. Elements of a SEQUENCE OF or
+// SET OF are addressed by their index, e.g. tbsCertificate.extensions[0].
+type Field struct {
+	Path   string
+	Offset int
+	asn1.RawValue
+}
+
+// element is a decoded TLV with its offset in the complete encoding
+type element struct {
+	header
+	offset int
+	value  asn1.RawValue
+}
+
+// schemaWalker walks over an encoding and the schema in parallel
+type schemaWalker struct {
+	e     *errors.Errors
+	visit func(Field)
+}
+
+// Check returns a list of structural deviations of der from the schema, each
+// deviation contains the path of the field.
+func (s *Schema) Check(der []byte) *errors.Errors {
+	var e = errors.New(nil)
+	w := schemaWalker{e: e}
+	w.walkRoot(der, s)
+	if e.IsError() {
+		return e
+	}
+	return nil
+}
+
+// Fields returns all fields of der that could be matched to the schema
+func (s *Schema) Fields(der []byte) []Field {
+	var fields []Field
+	w := schemaWalker{
+		e:     errors.New(nil),
+		visit: func(f Field) { fields = append(fields, f) },
+	}
+	w.walkRoot(der, s)
+	return fields
+}
+
+// Lookup returns the encoding of the field with the given path
+func (s *Schema) Lookup(der []byte, path string) (asn1.RawValue, error) {
+	for _, f := range s.Fields(der) {
+		if f.Path == path {
+			return f.RawValue, nil
+		}
+	}
+	return asn1.RawValue{}, fmt.Errorf("%s not found in %s", path, s.name)
+}
+
+// walkRoot verifies that der contains a single value of the schema
+func (w *schemaWalker) walkRoot(der []byte, s *Schema) {
+	elements, err := split(der, 0)
+	if err != nil {
+		w.e.Err("%s can't be decoded: %s", s.name, err.Error())
+		return
+	}
+	if len(elements) == 0 || !s.match(elements[0].header) {
+		w.e.Err("%s is not a %s", s.name, s.typeName())
+		return
+	}
+	if len(elements) > 1 {
+		w.e.Err("%s contains trailing data", s.name)
+	}
+	w.walk(s, "", elements[0])
+}
+
+// walk verifies a single element against the schema, the element is already
+// known to match the tag of the schema.
+func (w *schemaWalker) walk(s *Schema, path string, el element) {
+	// The alternative of a CHOICE is not included in the path, the field is
+	// visited once for the matched alternative.
+	if len(s.choice) > 0 {
+		for _, alt := range s.choice {
+			if alt.match(el.header) {
+				w.walk(alt, path, el)
+				return
+			}
+		}
+	}
+
+	// The path of an explicitly tagged field refers to the inner value
+	if w.visit != nil && s.explicit == nil {
+		w.visit(Field{Path: path, Offset: el.offset, RawValue: el.value})
+	}
+	label := path
+	if len(label) == 0 {
+		label = s.name
+	}
+	if s.any {
+		return
+	}
+
+	// X.690 8.1.2.5: the constructed flag is part of the type
+	if el.compound != s.compound {
+		if s.explicit != nil {
+			w.e.Err("%s MUST be explicitly tagged", label)
+		} else if s.implicit {
+			w.e.Err("%s MUST be implicitly tagged", label)
+		} else if s.compound {
+			w.e.Err("%s MUST use the constructed encoding", label)
+		} else {
+			w.e.Err("%s MUST use the primitive encoding (X.690 10.2)", label)
+		}
+		return
+	}
+
+	// X.690 11.5: the encoding of a set value or sequence value shall not
+	// include an encoding for any component value which is equal to its
+	// default value.
+	if s.def != nil && bytes.Equal(el.value.Bytes, s.def) {
+		w.e.Err("%s contains the DEFAULT value, which MUST NOT be encoded in DER (X.690 11.5)", label)
+	}
+
+	if !s.compound {
+		return
+	}
+
+	elements, err := split(el.value.Bytes, el.offset+el.size)
+	if err != nil {
+		w.e.Err("%s can't be decoded: %s", label, err.Error())
+		return
+	}
+
+	switch {
+	case s.explicit != nil:
+		if len(elements) != 1 || !s.explicit.match(elements[0].header) {
+			w.e.Err("%s MUST be explicitly tagged", label)
+			return
+		}
+		w.walk(s.explicit, path, elements[0])
+
+	case s.of != nil:
+		if len(elements) < s.min {
+			w.e.Err("%s contains fewer than %d elements", label, s.min)
+		}
+		for i, c := range elements {
+			p := path + "[" + strconv.Itoa(i) + "]"
+			if !s.of.match(c.header) {
+				w.e.Err("%s is not a %s", p, s.of.typeName())
+				continue
+			}
+			w.walk(s.of, p, c)
+		}
+
+	case len(s.fields) > 0:
+		var i int
+		for _, f := range s.fields {
+			if i < len(elements) && f.match(elements[i].header) {
+				w.walk(f, join(path, f.name), elements[i])
+				i++
+				continue
+			}
+			if !f.optional {
+				w.e.Err("%s is missing", join(path, f.name))
+			}
+		}
+
+		// Remaining elements are either encoded in a different order or not
+		// defined in the schema.
+		for _, c := range elements[i:] {
+			var found bool
+			for _, f := range s.fields {
+				if f.match(c.header) {
+					w.e.Err("%s is not in the expected order", join(path, f.name))
+					found = true
+					break
+				}
+			}
+			if !found {
+				w.e.Err("%s contains an unexpected element at offset %d", label, c.offset)
+			}
+		}
+	}
+}
+
+// match returns true if the tag of the header is allowed by the schema
+func (s *Schema) match(h header) bool {
+	if s.any {
+		return true
+	}
+	if len(s.choice) > 0 {
+		for _, alt := range s.choice {
+			if alt.match(h) {
+				return true
+			}
+		}
+		return false
+	}
+	return s.class == h.class && s.tag == h.tag
+}
+
+// typeName returns a readable name of the expected type
+func (s *Schema) typeName() string {
+	switch {
+	case s.any:
+		return "ANY"
+	case len(s.choice) > 0:
+		return "CHOICE"
+	case s.class == asn1.ClassContextSpecific:
+		return "[" + strconv.Itoa(s.tag) + "]"
+	case s.class == asn1.ClassUniversal && len(tagNames[s.tag]) > 0:
+		return tagNames[s.tag]
+	}
+	return "tag " + strconv.Itoa(s.tag)
+}
+
+var tagNames = map[int]string{
+	asn1.TagBoolean:         "BOOLEAN",
+	asn1.TagInteger:         "INTEGER",
+	asn1.TagBitString:       "BIT STRING",
+	asn1.TagOctetString:     "OCTET STRING",
+	asn1.TagNull:            "NULL",
+	asn1.TagOID:             "OBJECT IDENTIFIER",
+	asn1.TagEnum:            "ENUMERATED",
+	asn1.TagUTF8String:      "UTF8String",
+	asn1.TagSequence:        "SEQUENCE",
+	asn1.TagSet:             "SET",
+	asn1.TagPrintableString: "PrintableString",
+	asn1.TagIA5String:       "IA5String",
+	asn1.TagUTCTime:         "UTCTime",
+	asn1.TagGeneralizedTime: "GeneralizedTime",
+}
+
+// split decodes all TLVs in der, offset is the position of der in the
+// complete encoding.
+func split(der []byte, offset int) ([]element, error) {
+	var elements []element
+	for len(der) > 0 {
+		h, err := parseHeader(der)
+		if err != nil {
+			return nil, err
+		}
+		if h.length < 0 {
+			return nil, fmt.Errorf("Indefinite length")
+		}
+		if h.size+h.length > len(der) {
+			return nil, fmt.Errorf("Data truncated")
+		}
+
+		elements = append(elements, element{
+			header: h,
+			offset: offset,
+			value: asn1.RawValue{
+				Class:      h.class,
+				Tag:        h.tag,
+				IsCompound: h.compound,
+				Bytes:      der[h.size : h.size+h.length],
+				FullBytes:  der[:h.size+h.length],
+			},
+		})
+
+		der = der[h.size+h.length:]
+		offset += h.size + h.length
+	}
+	return elements, nil
+}
+
+// join returns the path of a component
+func join(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,180 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// tlv returns the DER encoding of a value with the given identifier octet
+func tlv(id byte, content ...[]byte) []byte {
+	c := bytes.Join(content, nil)
+	b := []byte{id}
+	if len(c) < 0x80 {
+		b = append(b, byte(len(c)))
+	} else {
+		b = append(b, 0x82, byte(len(c)>>8), byte(len(c)))
+	}
+	return append(b, c...)
+}
+
+var (
+	testAlgorithm = tlv(0x30, tlv(0x06, []byte{0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x01, 0x0b}), tlv(0x05))
+	testName      = tlv(0x30, tlv(0x31, tlv(0x30, tlv(0x06, []byte{0x55, 0x04, 0x03}), tlv(0x0c, []byte("test")))))
+	testValidity  = tlv(0x30, tlv(0x17, []byte("200101000000Z")), tlv(0x17, []byte("210101000000Z")))
+	testSPKI      = tlv(0x30, testAlgorithm, tlv(0x03, []byte{0x00, 0x01}))
+	testExtension = tlv(0x30, tlv(0x06, []byte{0x55, 0x1d, 0x13}), tlv(0x04, tlv(0x30)))
+	testVersion   = tlv(0xa0, tlv(0x02, []byte{0x02}))
+	testSerial    = tlv(0x02, []byte{0x01})
+)
+
+// testCertificate returns a certificate with the given TBSCertificate fields
+func testCertificate(fields ...[]byte) []byte {
+	return tlv(0x30, tlv(0x30, fields...), testAlgorithm, tlv(0x03, []byte{0x00, 0x01}))
+}
+
+func TestSchemaCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		DER            []byte
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: v3 certificate",
+			DER:  testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI, tlv(0xa3, tlv(0x30, testExtension))),
+		},
+		{
+			Name: "Valid: v1 certificate",
+			DER:  testCertificate(testSerial, testAlgorithm, testName, testValidity, testName, testSPKI),
+		},
+		{
+			Name: "Invalid: explicit v1 version",
+			DER:  testCertificate(tlv(0xa0, tlv(0x02, []byte{0x00})), testSerial, testAlgorithm, testName, testValidity, testName, testSPKI),
+			ExpectedErrors: []string{
+				"tbsCertificate.version contains the DEFAULT value, which MUST NOT be encoded in DER (X.690 11.5)",
+			},
+		},
+		{
+			Name: "Invalid: critical FALSE",
+			DER: testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI,
+				tlv(0xa3, tlv(0x30, tlv(0x30, tlv(0x06, []byte{0x55, 0x1d, 0x13}), tlv(0x01, []byte{0x00}), tlv(0x04, tlv(0x30)))))),
+			ExpectedErrors: []string{
+				"tbsCertificate.extensions[0].critical contains the DEFAULT value, which MUST NOT be encoded in DER (X.690 11.5)",
+			},
+		},
+		{
+			Name: "Invalid: missing serialNumber",
+			DER:  testCertificate(testVersion, testAlgorithm, testName, testValidity, testName, testSPKI),
+			ExpectedErrors: []string{
+				"tbsCertificate.serialNumber is missing",
+			},
+		},
+		{
+			Name: "Invalid: optional fields out of order",
+			DER:  testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI, tlv(0xa3, tlv(0x30, testExtension)), tlv(0x81, []byte{0x00})),
+			ExpectedErrors: []string{
+				"tbsCertificate.issuerUniqueID is not in the expected order",
+			},
+		},
+		{
+			Name: "Invalid: explicit tag on implicit field",
+			DER:  testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI, tlv(0xa1, tlv(0x03, []byte{0x00}))),
+			ExpectedErrors: []string{
+				"tbsCertificate.issuerUniqueID MUST be implicitly tagged",
+			},
+		},
+		{
+			Name: "Invalid: implicit tag on explicit field",
+			DER:  testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI, tlv(0xa3, testExtension, testExtension)),
+			ExpectedErrors: []string{
+				"tbsCertificate.extensions MUST be explicitly tagged",
+			},
+		},
+		{
+			Name: "Invalid: time type and empty RDN",
+			DER:  testCertificate(testSerial, testAlgorithm, tlv(0x30, tlv(0x31)), tlv(0x30, tlv(0x17, []byte("200101000000Z")), tlv(0x04)), testName, testSPKI),
+			ExpectedErrors: []string{
+				"tbsCertificate.issuer[0] contains fewer than 1 elements",
+				"tbsCertificate.validity.notAfter is missing",
+				"tbsCertificate.validity contains an unexpected element at offset 43",
+			},
+		},
+		{
+			Name: "Invalid: trailing data",
+			DER:  append(testCertificate(testSerial, testAlgorithm, testName, testValidity, testName, testSPKI), 0x05, 0x00),
+			ExpectedErrors: []string{
+				"Certificate contains trailing data",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var errList []errors.Err
+			if e := Certificate.Check(tc.DER); e != nil {
+				errList = e.List()
+			}
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	der := testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI, tlv(0xa3, tlv(0x30, testExtension)))
+
+	v, err := Certificate.Lookup(der, "tbsCertificate.validity.notAfter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Tag != asn1.TagUTCTime || string(v.Bytes) != "210101000000Z" {
+		t.Errorf("unexpected notAfter %v", v)
+	}
+
+	v, err = Certificate.Lookup(der, "tbsCertificate.version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Tag != asn1.TagInteger || !bytes.Equal(v.Bytes, []byte{0x02}) {
+		t.Errorf("unexpected version %v", v)
+	}
+
+	v, err = Certificate.Lookup(der, "tbsCertificate.extensions[0].extnID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Tag != asn1.TagOID || !bytes.Equal(v.Bytes, []byte{0x55, 0x1d, 0x13}) {
+		t.Errorf("unexpected extnID %v", v)
+	}
+
+	if _, err = Certificate.Lookup(der, "tbsCertificate.issuerUniqueID"); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+}
+
+func TestFields(t *testing.T) {
+	der := testCertificate(testVersion, testSerial, testAlgorithm, testName, testValidity, testName, testSPKI)
+
+	seen := make(map[string]bool)
+	for _, f := range Certificate.Fields(der) {
+		if seen[f.Path] {
+			t.Errorf("duplicate field %s", f.Path)
+		}
+		seen[f.Path] = true
+	}
+	for _, path := range []string{"tbsCertificate.validity.notBefore", "tbsCertificate.validity.notAfter"} {
+		if !seen[path] {
+			t.Errorf("missing field %s", path)
+		}
+	}
+}
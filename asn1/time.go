@@ -0,0 +1,67 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"strconv"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// noWellDefinedExpiration is the GeneralizedTime that is used when a
+// certificate has no well-defined expiration date (RFC 5280 4.1.2.5)
+var noWellDefinedExpiration = []byte("99991231235959Z")
+
+// CheckTime verifies the encoding of a Time value according to RFC 5280
+// 4.1.2.5, field contains the name that is used in the messages. The rules do
+// also apply to the times in CRLs and OCSP responses.
+//
+//  CAs conforming to this profile MUST always encode certificate validity
+//  dates through the year 2049 as UTCTime; certificate validity dates in 2050
+//  or later MUST be encoded as GeneralizedTime.
+//
+func CheckTime(field string, v asn1.RawValue) *errors.Errors {
+	var e = errors.New(nil)
+
+	if v.Class != asn1.ClassUniversal {
+		e.Err("%s is not encoded as UTCTime or GeneralizedTime", field)
+		return e
+	}
+
+	switch v.Tag {
+	case asn1.TagUTCTime:
+		// Two digit years represent 1950 through 2049 and can't be used for
+		// dates that must be encoded as GeneralizedTime.
+		if !formatUTCTime.Match(v.Bytes) {
+			e.Err("%s contains an invalid UTCTime (RFC 5280 4.1.2.5.1)", field)
+		}
+
+	case asn1.TagGeneralizedTime:
+		// RFC 5280 4.1.2.5.2: GeneralizedTime values MUST NOT include
+		// fractional seconds
+		if bytes.IndexAny(v.Bytes, ".,") > -1 {
+			e.Err("%s MUST NOT include fractional seconds (RFC 5280 4.1.2.5.2)", field)
+		} else if !formatGeneralizedTime.Match(v.Bytes) {
+			e.Err("%s contains an invalid GeneralizedTime (RFC 5280 4.1.2.5.2)", field)
+		}
+		if len(v.Bytes) < 4 {
+			return e
+		}
+
+		year, err := strconv.Atoi(string(v.Bytes[:4]))
+		if err != nil {
+			return e
+		}
+		if year < 2050 {
+			e.Err("%s MUST be encoded as UTCTime through the year 2049 (RFC 5280 4.1.2.5)", field)
+		}
+		if year == 9999 && !bytes.Equal(v.Bytes, noWellDefinedExpiration) {
+			e.Warning("%s SHOULD be 99991231235959Z to indicate no well-defined expiration date (RFC 5280 4.1.2.5)", field)
+		}
+
+	default:
+		e.Err("%s is not encoded as UTCTime or GeneralizedTime", field)
+	}
+
+	return e
+}
@@ -0,0 +1,78 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"testing"
+)
+
+func TestCheckTime(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Value          asn1.RawValue
+		ExpectedErrors []string
+	}{
+		{
+			Name:  "Valid: UTCTime in 2049",
+			Value: asn1.RawValue{Tag: asn1.TagUTCTime, Bytes: []byte("491231235959Z")},
+		},
+		{
+			Name:  "Valid: GeneralizedTime in 2050",
+			Value: asn1.RawValue{Tag: asn1.TagGeneralizedTime, Bytes: []byte("20500101000000Z")},
+		},
+		{
+			Name:  "Valid: no well-defined expiration date",
+			Value: asn1.RawValue{Tag: asn1.TagGeneralizedTime, Bytes: []byte("99991231235959Z")},
+		},
+		{
+			Name:  "Invalid: GeneralizedTime before 2050",
+			Value: asn1.RawValue{Tag: asn1.TagGeneralizedTime, Bytes: []byte("20491231235959Z")},
+			ExpectedErrors: []string{
+				"notAfter MUST be encoded as UTCTime through the year 2049 (RFC 5280 4.1.2.5)",
+			},
+		},
+		{
+			Name:  "Invalid: fractional seconds",
+			Value: asn1.RawValue{Tag: asn1.TagGeneralizedTime, Bytes: []byte("20500101000000.5Z")},
+			ExpectedErrors: []string{
+				"notAfter MUST NOT include fractional seconds (RFC 5280 4.1.2.5.2)",
+			},
+		},
+		{
+			Name:  "Invalid: year 9999",
+			Value: asn1.RawValue{Tag: asn1.TagGeneralizedTime, Bytes: []byte("99990101000000Z")},
+			ExpectedErrors: []string{
+				"notAfter SHOULD be 99991231235959Z to indicate no well-defined expiration date (RFC 5280 4.1.2.5)",
+			},
+		},
+		{
+			Name:  "Invalid: UTCTime without seconds",
+			Value: asn1.RawValue{Tag: asn1.TagUTCTime, Bytes: []byte("4912312359Z")},
+			ExpectedErrors: []string{
+				"notAfter contains an invalid UTCTime (RFC 5280 4.1.2.5.1)",
+			},
+		},
+		{
+			Name:  "Invalid: not a time",
+			Value: asn1.RawValue{Tag: asn1.TagPrintableString, Bytes: []byte("20500101000000Z")},
+			ExpectedErrors: []string{
+				"notAfter is not encoded as UTCTime or GeneralizedTime",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			errList := CheckTime("notAfter", tc.Value).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of CheckTime errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package certdata
 
 import (
+	"bytes"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
+	"time"
+
+	"github.com/globalsign/certlint/psl"
 )
 
 // Data holds the certificate and relevant information
@@ -11,6 +17,17 @@ type Data struct {
 	Cert   *x509.Certificate
 	Issuer *x509.Certificate
 	Type   string
+
+	// PSL is the public suffix list used to evaluate domain names, the default
+	// list is used when not set.
+	PSL psl.List
+
+	// AsOf evaluates the certificate as if it was issued on this date instead
+	// of notBefore, used to test against upcoming requirements.
+	AsOf time.Time
+
+	// Profile of the issuer, selected with SetProfile
+	Profile *Profile
 }
 
 // Load raw certificate bytes into a Data struct
@@ -23,6 +40,13 @@ func Load(der []byte) (*Data, error) {
 		return nil, err
 	}
 
+	// Evaluate against the public suffix list as of the issuance date
+	d.PSL, err = psl.At(d.Cert.NotBefore)
+	if err != nil {
+		fmt.Println(err)
+		d.PSL = psl.Default()
+	}
+
 	if err = d.setCertificateType(); err != nil {
 		fmt.Println(err)
 	}
@@ -40,3 +64,62 @@ func (d *Data) SetIssuer(der []byte) error {
 	}
 	return nil
 }
+
+// SetAsOf evaluates the certificate as if it was issued on the given date, the
+// public suffix list is selected again for this date.
+func (d *Data) SetAsOf(t time.Time) error {
+	d.AsOf = t
+
+	l, err := psl.At(d.IssuanceDate())
+	if err != nil {
+		return err
+	}
+	d.PSL = l
+	return nil
+}
+
+// IssuanceDate returns the date that is used to select the requirements that
+// apply to the certificate, this is notBefore unless AsOf is set.
+func (d *Data) IssuanceDate() time.Time {
+	if !d.AsOf.IsZero() {
+		return d.AsOf
+	}
+	return d.Cert.NotBefore
+}
+
+// ExpirationDate returns notAfter, shifted by the same period as the issuance
+// date when AsOf is set.
+func (d *Data) ExpirationDate() time.Time {
+	if !d.AsOf.IsZero() {
+		return d.AsOf.Add(d.Cert.NotAfter.Sub(d.Cert.NotBefore))
+	}
+	return d.Cert.NotAfter
+}
+
+// SelfSigned returns true if the certificate is issued and signed by its own
+// subject and key
+func (d *Data) SelfSigned() bool {
+	if !bytes.Equal(d.Cert.RawIssuer, d.Cert.RawSubject) {
+		return false
+	}
+	return d.Cert.CheckSignature(d.Cert.SignatureAlgorithm, d.Cert.RawTBSCertificate, d.Cert.Signature) == nil
+}
+
+// Extension returns the extension with the given object identifier
+func (d *Data) Extension(oid asn1.ObjectIdentifier) (pkix.Extension, bool) {
+	for _, ext := range d.Cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return ext, true
+		}
+	}
+	return pkix.Extension{}, false
+}
+
+// PublicSuffix returns the public suffix of the domain using the public
+// suffix list of the certificate
+func (d *Data) PublicSuffix(domain string) (string, bool) {
+	if d.PSL == nil {
+		return psl.Default().PublicSuffix(domain)
+	}
+	return d.PSL.PublicSuffix(domain)
+}
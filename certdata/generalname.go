@@ -0,0 +1,128 @@
+package certdata
+
+import (
+	"encoding/asn1"
+	"fmt"
+)
+
+// GeneralName tags as defined in RFC 5280 4.2.1.6
+const (
+	GeneralNameOther         = 0
+	GeneralNameRFC822        = 1
+	GeneralNameDNS           = 2
+	GeneralNameX400          = 3
+	GeneralNameDirectory     = 4
+	GeneralNameEDIParty      = 5
+	GeneralNameURI           = 6
+	GeneralNameIPAddress     = 7
+	GeneralNameRegisteredID  = 8
+	generalNameHighestChoice = GeneralNameRegisteredID
+)
+
+// GeneralNameType returns a readable name of the GeneralName choice
+func GeneralNameType(tag int) string {
+	switch tag {
+	case GeneralNameOther:
+		return "otherName"
+	case GeneralNameRFC822:
+		return "rfc822Name"
+	case GeneralNameDNS:
+		return "dNSName"
+	case GeneralNameX400:
+		return "x400Address"
+	case GeneralNameDirectory:
+		return "directoryName"
+	case GeneralNameEDIParty:
+		return "ediPartyName"
+	case GeneralNameURI:
+		return "uniformResourceIdentifier"
+	case GeneralNameIPAddress:
+		return "iPAddress"
+	case GeneralNameRegisteredID:
+		return "registeredID"
+	}
+	return fmt.Sprintf("unknown (%d)", tag)
+}
+
+// ParseGeneralName decodes a single GeneralName, the remaining bytes are
+// returned.
+func ParseGeneralName(b []byte) (asn1.RawValue, []byte, error) {
+	var gn asn1.RawValue
+	rest, err := asn1.Unmarshal(b, &gn)
+	if err != nil {
+		return gn, rest, err
+	}
+	if gn.Class != asn1.ClassContextSpecific || gn.Tag > generalNameHighestChoice {
+		return gn, rest, fmt.Errorf("invalid GeneralName tag (%d/%d)", gn.Class, gn.Tag)
+	}
+	return gn, rest, nil
+}
+
+// ParseGeneralNames decodes the content octets of a GeneralNames sequence
+func ParseGeneralNames(b []byte) ([]asn1.RawValue, error) {
+	var gns []asn1.RawValue
+	for len(b) > 0 {
+		gn, rest, err := ParseGeneralName(b)
+		if err != nil {
+			return gns, err
+		}
+		gns = append(gns, gn)
+		b = rest
+	}
+	return gns, nil
+}
+
+// OtherName as defined in RFC 5280 4.2.1.6, Value contains the value within
+// the explicit [0] tag.
+type OtherName struct {
+	TypeID asn1.ObjectIdentifier
+	Value  asn1.RawValue
+}
+
+// ParseOtherName decodes the otherName choice of a GeneralName
+func ParseOtherName(gn asn1.RawValue) (OtherName, error) {
+	var on OtherName
+	if gn.Class != asn1.ClassContextSpecific || gn.Tag != GeneralNameOther {
+		return on, fmt.Errorf("GeneralName is not an otherName (%s)", GeneralNameType(gn.Tag))
+	}
+	rest, err := asn1.UnmarshalWithParams(gn.FullBytes, &on, "tag:0")
+	if err != nil {
+		return on, err
+	}
+	if len(rest) > 0 {
+		return on, fmt.Errorf("otherName contains trailing data")
+	}
+
+	// A RawValue is not unwrapped from its explicit tag
+	if on.Value.Class != asn1.ClassContextSpecific || on.Value.Tag != 0 || !on.Value.IsCompound {
+		return on, fmt.Errorf("otherName value is not explicitly tagged")
+	}
+	rest, err = asn1.Unmarshal(on.Value.Bytes, &on.Value)
+	if err != nil {
+		return on, err
+	}
+	if len(rest) > 0 {
+		return on, fmt.Errorf("otherName value contains trailing data")
+	}
+	return on, nil
+}
+
+// SubjectAltNames decodes the GeneralNames of the subjectAltName extension, nil
+// is returned when the certificate has no subjectAltName extension.
+func (d *Data) SubjectAltNames() ([]asn1.RawValue, error) {
+	ext, ok := d.Extension(asn1.ObjectIdentifier{2, 5, 29, 17})
+	if !ok {
+		return nil, nil
+	}
+
+	var seq asn1.RawValue
+	if rest, err := asn1.Unmarshal(ext.Value, &seq); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("subjectAltName contains trailing data")
+	}
+	if seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence {
+		return nil, fmt.Errorf("subjectAltName is not a sequence")
+	}
+	return ParseGeneralNames(seq.Bytes)
+}
@@ -0,0 +1,82 @@
+package certdata
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+)
+
+// Key algorithms as identified by the algorithm of the SubjectPublicKeyInfo
+const (
+	KeyAlgorithmRSA       = "RSA"
+	KeyAlgorithmRSAPSS    = "RSA-PSS"
+	KeyAlgorithmECDSA     = "ECDSA"
+	KeyAlgorithmEd25519   = "Ed25519"
+	KeyAlgorithmEd448     = "Ed448"
+	KeyAlgorithmX25519    = "X25519"
+	KeyAlgorithmX448      = "X448"
+	KeyAlgorithmDSA       = "DSA"
+	KeyAlgorithmDH        = "DH"
+	KeyAlgorithmMLDSA44   = "ML-DSA-44"
+	KeyAlgorithmMLDSA65   = "ML-DSA-65"
+	KeyAlgorithmMLDSA87   = "ML-DSA-87"
+	KeyAlgorithmMLKEM512  = "ML-KEM-512"
+	KeyAlgorithmMLKEM768  = "ML-KEM-768"
+	KeyAlgorithmMLKEM1024 = "ML-KEM-1024"
+)
+
+var keyAlgorithms = []struct {
+	oid       asn1.ObjectIdentifier
+	algorithm string
+}{
+	{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}, KeyAlgorithmRSA},
+	{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}, KeyAlgorithmRSAPSS},
+	{asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, KeyAlgorithmECDSA},
+	{asn1.ObjectIdentifier{1, 3, 101, 112}, KeyAlgorithmEd25519},
+	{asn1.ObjectIdentifier{1, 3, 101, 113}, KeyAlgorithmEd448},
+	{asn1.ObjectIdentifier{1, 3, 101, 110}, KeyAlgorithmX25519},
+	{asn1.ObjectIdentifier{1, 3, 101, 111}, KeyAlgorithmX448},
+	{asn1.ObjectIdentifier{1, 2, 840, 10040, 4, 1}, KeyAlgorithmDSA},
+	{asn1.ObjectIdentifier{1, 2, 840, 10046, 2, 1}, KeyAlgorithmDH},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 17}, KeyAlgorithmMLDSA44},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18}, KeyAlgorithmMLDSA65},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}, KeyAlgorithmMLDSA87},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 1}, KeyAlgorithmMLKEM512},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}, KeyAlgorithmMLKEM768},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}, KeyAlgorithmMLKEM1024},
+}
+
+// SubjectPublicKeyInfo as defined in RFC 5280 4.1
+type SubjectPublicKeyInfo struct {
+	Algorithm        pkix.AlgorithmIdentifier
+	SubjectPublicKey asn1.BitString
+}
+
+// ParseSubjectPublicKeyInfo decodes a DER encoded SubjectPublicKeyInfo without
+// depending on the key algorithms supported by crypto/x509.
+func ParseSubjectPublicKeyInfo(der []byte) (*SubjectPublicKeyInfo, error) {
+	spki := new(SubjectPublicKeyInfo)
+	if _, err := asn1.Unmarshal(der, spki); err != nil {
+		return nil, err
+	}
+	return spki, nil
+}
+
+// KeyAlgorithm returns the name of the key algorithm of the given OID, an
+// empty string is returned for unknown algorithms.
+func KeyAlgorithm(oid asn1.ObjectIdentifier) string {
+	for _, ka := range keyAlgorithms {
+		if ka.oid.Equal(oid) {
+			return ka.algorithm
+		}
+	}
+	return ""
+}
+
+// KeyAlgorithm returns the key algorithm of the certificate public key
+func (d *Data) KeyAlgorithm() string {
+	spki, err := ParseSubjectPublicKeyInfo(d.Cert.RawSubjectPublicKeyInfo)
+	if err != nil {
+		return ""
+	}
+	return KeyAlgorithm(spki.Algorithm.Algorithm)
+}
@@ -0,0 +1,110 @@
+package certdata
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// Profile binds the certificates of an issuing CA to a set of checks and
+// settings, the issuer is identified by its subject key identifier or its
+// subject DN (as returned by pkix.Name.String).
+type Profile struct {
+	Name        string
+	IssuerKeyID [][]byte
+	IssuerDN    []string
+
+	// Checks that are performed, all checks when empty. Disabled checks are
+	// never performed.
+	Checks   []string
+	Disabled []string
+
+	// Priorities overrides the priority of all findings of a check
+	Priorities map[string]errors.Priority
+
+	// Type replaces the detected certificate type when set
+	Type string
+}
+
+var (
+	profiles     []*Profile
+	profileMutex sync.RWMutex
+)
+
+// SetProfiles sets the profiles that are matched against the issuer of a
+// certificate, the first matching profile is used.
+func SetProfiles(p []*Profile) {
+	profileMutex.Lock()
+	profiles = p
+	profileMutex.Unlock()
+}
+
+// SetProfile selects the profile of the issuer, the issuer should be known
+// before the profile is selected. It returns false when no profile matches.
+func (d *Data) SetProfile() bool {
+	profileMutex.RLock()
+	defer profileMutex.RUnlock()
+
+	for _, p := range profiles {
+		if !p.Match(d) {
+			continue
+		}
+		d.Profile = p
+		if len(p.Type) > 0 {
+			d.Type = p.Type
+		}
+		return true
+	}
+	return false
+}
+
+// Match returns true if the certificate is issued by the issuer of the
+// profile, the authority key identifier and issuer DN of the certificate are
+// used when the issuer certificate is not known.
+func (p *Profile) Match(d *Data) bool {
+	keyID, dn := d.Cert.AuthorityKeyId, d.Cert.Issuer.String()
+	if d.Issuer != nil {
+		if len(d.Issuer.SubjectKeyId) > 0 {
+			keyID = d.Issuer.SubjectKeyId
+		}
+		dn = d.Issuer.Subject.String()
+	}
+
+	for _, id := range p.IssuerKeyID {
+		if len(keyID) > 0 && bytes.Equal(id, keyID) {
+			return true
+		}
+	}
+	for _, n := range p.IssuerDN {
+		if n == dn {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled returns true if the check is performed for this profile
+func (p *Profile) Enabled(check string) bool {
+	for _, c := range p.Disabled {
+		if c == check {
+			return false
+		}
+	}
+	if len(p.Checks) == 0 {
+		return true
+	}
+	for _, c := range p.Checks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+// Priority returns the priority that replaces the priority of the findings of
+// a check
+func (p *Profile) Priority(check string) (errors.Priority, bool) {
+	priority, ok := p.Priorities[check]
+	return priority, ok
+}
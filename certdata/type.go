@@ -5,8 +5,6 @@ import (
 	"encoding/asn1"
 	"fmt"
 	"strings"
-
-	psl "golang.org/x/net/publicsuffix"
 )
 
 // setCertificateType set the base on how we check for other requirements of the
@@ -88,7 +86,7 @@ func (d *Data) setCertificateType() error {
 	}
 
 	// If it's a fqdn, it's a EV, OV or DV
-	if suffix, _ := psl.PublicSuffix(strings.ToLower(d.Cert.Subject.CommonName)); len(suffix) > 0 {
+	if suffix, _ := d.PublicSuffix(strings.ToLower(d.Cert.Subject.CommonName)); len(suffix) > 0 {
 		if len(d.Cert.Subject.Organization) > 0 {
 			if len(d.Cert.Subject.SerialNumber) > 0 {
 				d.Type = "EV"
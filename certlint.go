@@ -27,7 +27,10 @@ import (
 	"github.com/globalsign/certlint/asn1"
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/confusable"
+	"github.com/globalsign/certlint/checks/certificate/publickey/goodkey"
 	"github.com/globalsign/certlint/errors"
+	"github.com/globalsign/certlint/psl"
 
 	// Import all available checks
 	_ "github.com/globalsign/certlint/checks/certificate/all"
@@ -46,7 +49,12 @@ type testResult struct {
 	Cert    *x509.Certificate
 	Pem     string
 	Der     []byte
+	PSL     string
+	Profile string
 	Errors  *errors.Errors
+
+	// Shared is set for the dedicated result of a shared prime factor
+	Shared *sharedKey
 }
 
 // if errors package changes, this must change
@@ -69,6 +77,7 @@ var wgSave sync.WaitGroup
 var wgBulk sync.WaitGroup
 var intPool *x509.CertPool
 var trusted bool
+var asOf time.Time
 
 func main() {
 	var cert = flag.String("cert", "", "Certificate file")
@@ -78,6 +87,12 @@ func main() {
 	var report = flag.String("report", "report.csv", "Report filename")
 	var include = flag.Bool("include", false, "Include certificates in report")
 	var revoked = flag.Bool("revoked", false, "Check if certificates are revoked")
+	var weakKeys = flag.String("debianweakkeys", "", "Debian weak key blocklist file")
+	var brands = flag.String("brands", "", "Brand and domain names file for confusable name detection")
+	var configFile = flag.String("config", "", "Configuration file (JSON)")
+	var pslFile = flag.String("psl", "", "Public suffix list file (public_suffix_list.dat)")
+	var pslArchive = flag.String("pslarchive", "", "Directory with dated public suffix list snapshots (YYYY-MM-DD.dat)")
+	var asOfDate = flag.String("asof", "", "Evaluate certificates as if issued on this date (YYYY-MM-DD)")
 	trusted = *flag.Bool("trusted", false, "Only check trusted certificates")
 	var flagErr = flag.String("errlevel", "error", "Exit non-zero for Errors at this level")
 	var pprof = flag.String("pprof", "", "Generate pprof profile (cpu,mem,trace)")
@@ -122,6 +137,52 @@ func main() {
 		intPool.AppendCertsFromPEM(data)
 	}
 
+	// Load the configuration file
+	if len(*configFile) > 0 {
+		if err := loadConfig(*configFile); err != nil {
+			log.Fatal("Failed to load configuration:", err)
+		}
+	}
+
+	// Load the Debian weak key blocklist
+	if len(*weakKeys) > 0 {
+		if err := goodkey.LoadDebianBlocklist(*weakKeys); err != nil {
+			log.Fatal("Failed to load Debian weak key blocklist:", err)
+		}
+	}
+
+	// Load the brand names that are checked for confusable names
+	if len(*brands) > 0 {
+		if err := confusable.LoadBrands(*brands); err != nil {
+			log.Fatal("Failed to load brand names:", err)
+		}
+	}
+
+	// Load the public suffix list, the builtin list is used by default
+	if len(*pslFile) > 0 {
+		l, err := psl.LoadFile(*pslFile)
+		if err != nil {
+			log.Fatal("Failed to load public suffix list:", err)
+		}
+		psl.SetDefault(l)
+	}
+	if len(*pslArchive) > 0 {
+		a, err := psl.LoadArchive(*pslArchive)
+		if err != nil {
+			log.Fatal("Failed to load public suffix list archive:", err)
+		}
+		psl.SetArchive(a)
+	}
+
+	// Evaluate the certificates against the requirements of another date
+	if len(*asOfDate) > 0 {
+		t, err := time.Parse("2006-01-02", *asOfDate)
+		if err != nil {
+			log.Fatal("Failed to parse -asof date:", err)
+		}
+		asOf = t
+	}
+
 	// Start the bulk checking logic to parse a pem file with more certificates and
 	// save the results to a csv file.
 	if len(*bulk) > 0 {
@@ -138,6 +199,9 @@ func main() {
 		fmt.Println("Finshed reading bulk file, waiting for processing to finish")
 		wgBulk.Wait()
 
+		fmt.Println("Checking for shared prime factors")
+		checkSharedFactors()
+
 		close(results)
 
 		fmt.Println("Processing finished, waiting till all results are saved")
@@ -151,6 +215,12 @@ func main() {
 	result := do(nil, der, *expired, true)
 
 	fmt.Println("Processed Certificate Type:", result.Type)
+	if len(*pslFile) > 0 || len(*pslArchive) > 0 {
+		fmt.Println("Public Suffix List:", result.PSL)
+	}
+	if len(result.Profile) > 0 {
+		fmt.Println("Profile:", result.Profile)
+	}
 	if result.Errors != nil {
 		fmt.Printf("Certificate Errors: %d\n", len(result.Errors.List()))
 		for _, err := range result.Errors.List() {
@@ -162,6 +232,13 @@ func main() {
 	}
 }
 
+// Names of the ASN.1 linter checks, these can be used in a profile like the
+// registered checks.
+const (
+	asn1StructCheck = "ASN.1 Structure Check"
+	asn1SchemaCheck = "ASN.1 Schema Check"
+)
+
 // do performs the checks on the der encoding and the actual certificate, if exp
 // is set true it will also check expired certificates.
 func do(icaCache *lru.Cache, der []byte, exp, rtrn bool) testResult {
@@ -172,122 +249,165 @@ func do(icaCache *lru.Cache, der []byte, exp, rtrn bool) testResult {
 	// Include der in results for debugging
 	result.Der = der
 
-	// This causes that we check every certificate, even expired certificates
-	al := new(asn1.Linter)
-	result.Errors.Append(al.CheckStruct(der))
-
 	// Load certificate
 	d, err := certdata.Load(der)
 	if err != nil {
 		result.Errors.Err(err.Error())
-	} else {
-		result.Trusted = true
-		result.Cert = d.Cert
-		result.Type = d.Type
+	}
+	checked := err == nil && checkCertificate(icaCache, d, &result, exp)
 
-		// Indication to not check this type of certificate
-		if d.Type == "-" {
-			return result
-		}
+	// This causes that we check every certificate, even expired certificates,
+	// the profile of the issuer applies once it is known.
+	e := checkASN1(der, d)
+	e.Append(result.Errors)
+	result.Errors = e
 
-		// Check if we need to skip expired certificates
-		if !exp && d.Cert.NotAfter.Before(time.Now()) {
-			return result
-		}
+	// Skipped certificates are not reported in batch mode
+	if err == nil && !checked {
+		return result
+	}
+
+	// In batch mode we want to find shared factors between all keys
+	if checked && !rtrn {
+		addModulus(result)
+	}
+
+	// In batch mode we want to queue results
+	if !rtrn && result.Errors.IsError() {
+		results <- result
+	}
+
+	return result
+}
 
-		// Check if this is a publicly trusted certificate
-		opts := x509.VerifyOptions{
-			CurrentTime:   d.Cert.NotBefore,
-			Intermediates: intPool,
-			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+// checkCertificate looks up the issuer and profile of the certificate and
+// performs the certificate checks, it returns false if the certificate is
+// skipped.
+func checkCertificate(icaCache *lru.Cache, d *certdata.Data, result *testResult, exp bool) bool {
+	if !asOf.IsZero() {
+		if err := d.SetAsOf(asOf); err != nil {
+			fmt.Println(err)
 		}
+	}
 
-		chain, err := d.Cert.Verify(opts)
-		if err == nil && len(chain) > 0 && len(chain[0]) > 1 {
-			d.Issuer = chain[0][1]
+	result.Trusted = true
+	result.Cert = d.Cert
+	result.Type = d.Type
+	result.PSL = d.PSL.Version()
 
-		} else {
-			// Issuer not in default pool, use issuer from AIA cache, download if
-			// not in cache and when certificate has not expired.
-			pool := intPool
-			type issuerCache struct {
-				Trusted bool
-				Issuer  *x509.Certificate
-				Pool    *x509.CertPool
-			}
+	// Indication to not check this type of certificate
+	if d.Type == "-" {
+		return false
+	}
 
-			var key string
+	// Check if we need to skip expired certificates
+	if !exp && d.Cert.NotAfter.Before(time.Now()) {
+		return false
+	}
 
-			// Create a unique ID to cache the chain of this issuer
-			if len(d.Cert.IssuingCertificateURL) > 0 {
-				// Same issuer can have multiple issuing URL's (cross certificates), we
-				// want to test with the provided information
-				key = fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprint(d.Cert.IssuingCertificateURL))))
+	// Check if this is a publicly trusted certificate
+	opts := x509.VerifyOptions{
+		CurrentTime:   d.Cert.NotBefore,
+		Intermediates: intPool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
 
-			} else if len(d.Cert.AuthorityKeyId) > 0 {
-				// If no issuer is given we use the AuthorityKeyId to identify the chain
-				key = fmt.Sprintf("%x", d.Cert.AuthorityKeyId)
+	chain, err := d.Cert.Verify(opts)
+	if err == nil && len(chain) > 0 && len(chain[0]) > 1 {
+		d.Issuer = chain[0][1]
 
-			} else {
-				// If we also have no AKI the only thing left is the raw DN of the issuer
-				key = fmt.Sprintf("%x", sha1.Sum(d.Cert.RawIssuer))
-			}
+	} else {
+		// Issuer not in default pool, use issuer from AIA cache, download if
+		// not in cache and when certificate has not expired.
+		pool := intPool
+		type issuerCache struct {
+			Trusted bool
+			Issuer  *x509.Certificate
+			Pool    *x509.CertPool
+		}
 
-			// try to get from lru cache
-			var cache interface{}
-			var ok bool
+		var key string
 
-			if icaCache != nil {
-				cache, ok = icaCache.Get(key)
-			}
-			if ok {
-				ic := cache.(issuerCache)
-				result.Trusted = ic.Trusted
-				d.Issuer = ic.Issuer
-				pool = ic.Pool
+		// Create a unique ID to cache the chain of this issuer
+		if len(d.Cert.IssuingCertificateURL) > 0 {
+			// Same issuer can have multiple issuing URL's (cross certificates), we
+			// want to test with the provided information
+			key = fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprint(d.Cert.IssuingCertificateURL))))
 
-			} else {
-				var e = errors.New(nil)
-				d.Issuer, pool, e = getIssuerPool(d.Cert)
-				result.Errors.Append(e)
-
-				// Check if this is a publicly trusted certificate
-				opts := x509.VerifyOptions{
-					CurrentTime:   d.Cert.NotBefore,
-					Intermediates: pool,
-					KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
-				}
-				if _, err = d.Cert.Verify(opts); err != nil {
-					result.Trusted = false
-				}
+		} else if len(d.Cert.AuthorityKeyId) > 0 {
+			// If no issuer is given we use the AuthorityKeyId to identify the chain
+			key = fmt.Sprintf("%x", d.Cert.AuthorityKeyId)
 
-				// Save pool in cache
-				if pool != nil && icaCache != nil {
-					icaCache.Add(key, issuerCache{result.Trusted, d.Issuer, pool})
-				}
-			}
+		} else {
+			// If we also have no AKI the only thing left is the raw DN of the issuer
+			key = fmt.Sprintf("%x", sha1.Sum(d.Cert.RawIssuer))
 		}
 
-		if trusted && !result.Trusted {
-			fmt.Printf("Failed to verify chain for %s\n", d.Cert.Issuer.CommonName)
-			result.Errors.Err("Failed to verify chain for %s\n", d.Cert.Issuer.CommonName)
-			return result
+		// try to get from lru cache
+		var cache interface{}
+		var ok bool
+
+		if icaCache != nil {
+			cache, ok = icaCache.Get(key)
 		}
+		if ok {
+			ic := cache.(issuerCache)
+			result.Trusted = ic.Trusted
+			d.Issuer = ic.Issuer
+			pool = ic.Pool
+
+		} else {
+			var e = errors.New(nil)
+			d.Issuer, pool, e = getIssuerPool(d.Cert)
+			result.Errors.Append(e)
+
+			// Check if this is a publicly trusted certificate
+			opts := x509.VerifyOptions{
+				CurrentTime:   d.Cert.NotBefore,
+				Intermediates: pool,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			}
+			if _, err = d.Cert.Verify(opts); err != nil {
+				result.Trusted = false
+			}
 
-		if d.Issuer == nil {
-			fmt.Printf("Incomplete chain for %s %s %x %v\n", d.Cert.Issuer.CommonName, d.Cert.Subject.CommonName, d.Cert.SerialNumber, result.Errors)
+			// Save pool in cache
+			if pool != nil && icaCache != nil {
+				icaCache.Add(key, issuerCache{result.Trusted, d.Issuer, pool})
+			}
 		}
+	}
 
-		// Check against errors
-		result.Errors.Append(checks.Certificate.Check(d))
+	if trusted && !result.Trusted {
+		fmt.Printf("Failed to verify chain for %s\n", d.Cert.Issuer.CommonName)
+		result.Errors.Err("Failed to verify chain for %s\n", d.Cert.Issuer.CommonName)
+		return false
 	}
 
-	// In batch mode we want to queue results
-	if !rtrn && result.Errors.IsError() {
-		results <- result
+	if d.Issuer == nil {
+		fmt.Printf("Incomplete chain for %s %s %x %v\n", d.Cert.Issuer.CommonName, d.Cert.Subject.CommonName, d.Cert.SerialNumber, result.Errors)
 	}
 
-	return result
+	// Apply the profile of the issuer, the type can be overridden
+	if d.SetProfile() {
+		result.Profile = d.Profile.Name
+		result.Type = d.Type
+	}
+
+	// Check against errors
+	result.Errors.Append(checks.Certificate.Check(d))
+
+	return true
+}
+
+// checkASN1 lints the DER encoding of the certificate, d is nil when the
+// certificate could not be loaded.
+func checkASN1(der []byte, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+	al := new(asn1.Linter)
+	e.Append(checks.Run(asn1StructCheck, d, func() *errors.Errors { return al.CheckStruct(der) }))
+	e.Append(checks.Run(asn1SchemaCheck, d, func() *errors.Errors { return asn1.Certificate.Check(der) }))
+	return e
 }
 
 func doBulk(bulk string) {
@@ -365,7 +485,7 @@ func saveResults(filename string, include, revoked bool) error {
 
 	writer := csv.NewWriter(file)
 	writer.UseCRLF = true
-	writer.Write([]string{"Issuer", "CN", "O", "Serial", "NotBefore", "NotAfter", "Type", "Priority", "Error", "Revoked", "Cert", "Fingerprint"})
+	writer.Write([]string{"Issuer", "CN", "O", "Serial", "NotBefore", "NotAfter", "Type", "Priority", "Error", "Revoked", "Cert", "Fingerprint", "PSL", "Profile"})
 	writer.Flush()
 
 	for {
@@ -382,7 +502,10 @@ func saveResults(filename string, include, revoked bool) error {
 		// Add all errors to file
 		for _, e := range r.Errors.List() {
 			var columns []string
-			if r.Cert != nil {
+			if r.Shared != nil {
+				columns = []string{r.Shared.Issuer, "", "", r.Shared.Serial, "", "", "", e.Priority().String(), e.Error(), "", "", hex.EncodeToString(r.Shared.Fingerprint[:]), "", ""}
+
+			} else if r.Cert != nil {
 				columns = []string{
 					fmt.Sprintf("%s, %s", r.Cert.Issuer.CommonName, r.Cert.Issuer.Organization),
 					r.Cert.Subject.CommonName,
@@ -423,6 +546,12 @@ func saveResults(filename string, include, revoked bool) error {
 				fingerprint := sha256.Sum256(r.Der)
 				columns = append(columns, hex.EncodeToString(fingerprint[:]))
 
+				// Version of the public suffix list the certificate is evaluated with
+				columns = append(columns, r.PSL)
+
+				// Profile of the issuer that is applied
+				columns = append(columns, r.Profile)
+
 			} else {
 				columns = []string{"", "", "", "", "", "", "", e.Priority().String(), e.Error(), "", r.Pem}
 			}
@@ -502,14 +631,6 @@ func getIssuer(cert *x509.Certificate) (*x509.Certificate, *errors.Errors) {
 		}
 	}
 
-	// check if the signature on this certificate can be verified with the downloaded issuer certificate
-	if issuer != nil {
-		err := cert.CheckSignatureFrom(issuer)
-		if err != nil {
-			e.Err("Signature not from downloaded issuer: %s", err.Error())
-		}
-	}
-
 	return issuer, e
 }
 
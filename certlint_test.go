@@ -246,3 +246,75 @@ func BenchmarkCertGood(b *testing.B) {
 		checks.Certificate.Check(d)
 	}
 }
+
+func TestLoadConfigProfiles(t *testing.T) {
+	defer certdata.SetProfiles(nil)
+
+	testCases := []struct {
+		Name          string
+		Config        string
+		ExpectedError string
+	}{
+		{
+			Name:   "Valid: profile",
+			Config: `{"profiles": [{"name": "Private TLS", "issuerKeyIds": ["01:02"], "disabled": ["Validity Check"], "priorities": {"Subject Check": "warning"}}]}`,
+		},
+		{
+			Name:   "Valid: ASN.1 linter checks",
+			Config: `{"profiles": [{"name": "Private TLS", "issuerKeyIds": ["0102"], "disabled": ["ASN.1 Structure Check"], "priorities": {"ASN.1 Schema Check": "notice"}}]}`,
+		},
+		{
+			Name:          "Invalid: unknown check",
+			Config:        `{"profiles": [{"name": "Private TLS", "issuerKeyIds": ["0102"], "checks": ["Validity Chek"]}]}`,
+			ExpectedError: "profile Private TLS contains an unknown check Validity Chek",
+		},
+		{
+			Name:          "Invalid: unknown check priority",
+			Config:        `{"profiles": [{"name": "Private TLS", "issuerKeyIds": ["0102"], "priorities": {"Subject": "notice"}}]}`,
+			ExpectedError: "profile Private TLS contains an unknown check Subject",
+		},
+		{
+			Name:          "Invalid: duplicate profile",
+			Config:        `{"profiles": [{"name": "Private TLS", "issuerKeyIds": ["0102"]}, {"name": "Private TLS", "issuerKeyIds": ["0304"]}]}`,
+			ExpectedError: "profile Private TLS is defined more than once",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "certlint")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(f.Name())
+			if _, err = f.WriteString(tc.Config); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+
+			err = loadConfig(f.Name())
+			switch {
+			case len(tc.ExpectedError) == 0 && err != nil:
+				t.Errorf("unexpected error %q", err)
+			case len(tc.ExpectedError) > 0 && (err == nil || err.Error() != tc.ExpectedError):
+				t.Errorf("expected error %q, got %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestCheckASN1Profile(t *testing.T) {
+	// INTEGER with a redundant leading zero in a truncated SEQUENCE
+	der := []byte{0x30, 0x04, 0x02, 0x02, 0x00, 0x01}
+	if len(checkASN1(der, nil).List()) == 0 {
+		t.Fatal("expected ASN.1 findings without a profile")
+	}
+
+	d := &certdata.Data{Profile: &certdata.Profile{
+		Name:     "Private PKI",
+		Disabled: []string{asn1StructCheck, asn1SchemaCheck},
+	}}
+	if errList := checkASN1(der, d).List(); len(errList) != 0 {
+		t.Errorf("expected the profile to disable the ASN.1 findings, got %v", errList)
+	}
+}
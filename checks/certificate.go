@@ -35,8 +35,48 @@ func (c certificate) Check(d *certdata.Data) *errors.Errors {
 		if cc.filter != nil && !cc.filter.Check(d) {
 			continue
 		}
-		e.Append(cc.f(d))
+		e.Append(Run(cc.name, d, func() *errors.Errors { return cc.f(d) }))
 	}
 
 	return e
 }
+
+// Registered returns true if a certificate or extension check with the given
+// name is registered
+func Registered(name string) bool {
+	certMutex.Lock()
+	defer certMutex.Unlock()
+	for _, cc := range Certificate {
+		if cc.name == name {
+			return true
+		}
+	}
+
+	extMutex.Lock()
+	defer extMutex.Unlock()
+	for _, ec := range Extensions {
+		if ec.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run performs a check unless it is disabled by the profile of the issuer, the
+// profile can override the priority of the findings. Checks that are not
+// registered, like the ASN.1 linter, can use it to respect the profile, d is
+// nil when the certificate could not be loaded.
+func Run(name string, d *certdata.Data, f func() *errors.Errors) *errors.Errors {
+	if d == nil || d.Profile == nil {
+		return f()
+	}
+	if !d.Profile.Enabled(name) {
+		return nil
+	}
+
+	e := f()
+	if p, ok := d.Profile.Priority(name); ok {
+		e.SetPriority(p)
+	}
+	return e
+}
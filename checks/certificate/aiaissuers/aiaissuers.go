@@ -1,8 +1,6 @@
 package aiaissuers
 
 import (
-	"net/url"
-
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
 	"github.com/globalsign/certlint/errors"
@@ -42,15 +40,5 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
-	for _, icu := range d.Cert.IssuingCertificateURL {
-		l, err := url.Parse(icu)
-		if err != nil {
-			e.Err("Certificate contains an invalid Authority Info Access Issuer URL (%s)", icu)
-		}
-		if l.Scheme != "http" {
-			e.Warning("Certificate contains a Authority Info Access Issuer with an non-preferred scheme (%s)", l.Scheme)
-		}
-	}
-
 	return e
 }
@@ -4,15 +4,18 @@ import (
 	// Import all default checks
 	_ "github.com/globalsign/certlint/checks/certificate/aiaissuers"
 	_ "github.com/globalsign/certlint/checks/certificate/basicconstraints"
+	_ "github.com/globalsign/certlint/checks/certificate/confusable"
 	_ "github.com/globalsign/certlint/checks/certificate/extensions"
 	_ "github.com/globalsign/certlint/checks/certificate/extkeyusage"
 	_ "github.com/globalsign/certlint/checks/certificate/internal"
 	_ "github.com/globalsign/certlint/checks/certificate/issuerdn"
+	_ "github.com/globalsign/certlint/checks/certificate/keyidentifier"
 	_ "github.com/globalsign/certlint/checks/certificate/keyusage"
 	_ "github.com/globalsign/certlint/checks/certificate/publickey"
 	_ "github.com/globalsign/certlint/checks/certificate/publicsuffix"
 	_ "github.com/globalsign/certlint/checks/certificate/revocation"
 	_ "github.com/globalsign/certlint/checks/certificate/serialnumber"
+	_ "github.com/globalsign/certlint/checks/certificate/signature"
 	_ "github.com/globalsign/certlint/checks/certificate/signaturealgorithm"
 	_ "github.com/globalsign/certlint/checks/certificate/subject"
 	_ "github.com/globalsign/certlint/checks/certificate/subjectaltname"
@@ -0,0 +1,82 @@
+package confusable
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultBrands are well-known names that are frequently imitated
+var defaultBrands = []string{
+	"amazon", "apple", "bankofamerica", "chase", "dropbox", "ebay",
+	"facebook", "github", "globalsign", "google", "icloud", "instagram",
+	"linkedin", "microsoft", "netflix", "office365", "outlook", "paypal",
+	"twitter", "wellsfargo", "whatsapp", "yahoo",
+}
+
+var brands = struct {
+	sync.RWMutex
+	skeletons map[string]string
+}{skeletons: skeletonMap(defaultBrands)}
+
+// SetBrands replaces the list of brand and domain names that are checked for
+// confusable names, nil restores the default list.
+func SetBrands(list []string) {
+	if list == nil {
+		list = defaultBrands
+	}
+	m := skeletonMap(list)
+
+	brands.Lock()
+	defer brands.Unlock()
+	brands.skeletons = m
+}
+
+// LoadBrands reads a file with one brand or domain name label per line, empty
+// lines and lines starting with # are ignored.
+func LoadBrands(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var list []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	SetBrands(list)
+	return nil
+}
+
+// confusableBrand returns the brand a label imitates, a label that equals
+// the brand is not reported.
+func confusableBrand(label string) (string, bool) {
+	brands.RLock()
+	defer brands.RUnlock()
+
+	brand, ok := brands.skeletons[skeleton(label)]
+	if !ok || brand == strings.ToLower(label) {
+		return "", false
+	}
+	return brand, true
+}
+
+// skeletonMap indexes the names by their skeleton
+func skeletonMap(list []string) map[string]string {
+	m := make(map[string]string, len(list))
+	for _, b := range list {
+		m[skeleton(b)] = b
+	}
+	return m
+}
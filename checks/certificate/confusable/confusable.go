@@ -0,0 +1,118 @@
+package confusable
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+
+	"golang.org/x/net/idna"
+)
+
+const checkName = "Confusable Names Check"
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, nil, Check)
+}
+
+// Check reports deceptive content in domain names and subject values, all
+// findings are reported as warning to be reviewed by the vetting team.
+//
+// http://www.unicode.org/reports/tr39/
+//
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	if strings.Contains(d.Cert.Subject.CommonName, ".") {
+		checkDomainName(e, "Certificate common name", d.Cert.Subject.CommonName)
+	}
+	for _, n := range d.Cert.DNSNames {
+		checkDomainName(e, "Certificate subjectAltName", n)
+	}
+
+	for _, n := range d.Cert.Subject.Names {
+		if s, ok := n.Value.(string); ok && !isASCII(s) {
+			checkText(e, "Subject attribute "+n.Type.String(), s)
+		}
+	}
+
+	return e
+}
+
+// checkDomainName checks the labels of a domain name, A-labels are decoded
+// to their Unicode form.
+func checkDomainName(e *errors.Errors, field, name string) {
+	for _, label := range strings.Split(name, ".") {
+		if strings.HasPrefix(strings.ToLower(label), "xn--") {
+			u, err := idna.Punycode.ToUnicode(strings.ToLower(label))
+			if err != nil {
+				// Invalid A-labels are reported by the subjectAltName check
+				continue
+			}
+			label = u
+		}
+
+		field := field + " '" + name + "' label"
+		checkText(e, field, label)
+
+		if brand, ok := confusableBrand(label); ok {
+			e.Warning("%s '%s' is confusable with '%s'", field, label, brand)
+		}
+	}
+}
+
+// checkText reports invisible characters, mixed scripts and text that is
+// confusable with an ASCII string.
+func checkText(e *errors.Errors, field, s string) {
+	if !utf8.ValidString(s) {
+		return
+	}
+
+	var bidi, zeroWidth bool
+	for _, r := range s {
+		bidi = bidi || isBidiControl(r)
+		zeroWidth = zeroWidth || isZeroWidth(r)
+	}
+	if bidi {
+		e.Warning("%s '%s' contains a bidirectional control character", field, s)
+	}
+	if zeroWidth {
+		e.Warning("%s '%s' contains a zero-width character", field, s)
+	}
+
+	if list, mixed := mixedScripts(s); mixed {
+		e.Warning("%s '%s' contains mixed scripts (%s)", field, s, list)
+	}
+
+	// Non-ASCII text of which all characters are confusable with ASCII,
+	// invisible characters are reported above
+	if sk := skeleton(s); isASCII(sk) && hasConfusable(s) {
+		e.Warning("%s '%s' is confusable with '%s' (UTS #39)", field, s, sk)
+	}
+}
+
+// hasConfusable returns true if s contains a non-ASCII character that is
+// confusable with an ASCII character
+func hasConfusable(s string) bool {
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		if _, ok := confusables[r]; ok || (r >= 0xff01 && r <= 0xff5e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isASCII returns true if s only contains ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package confusable
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+
+	"golang.org/x/net/idna"
+)
+
+func TestCheck(t *testing.T) {
+	// Cyrillic е, х, а and р mixed with Latin m and l
+	homoglyph, err := idna.Punycode.ToASCII("ехаmрlе")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name           string
+		DNSNames       []string
+		Subject        []pkix.AttributeTypeAndValue
+		ExpectedErrors []string
+	}{
+		{
+			Name:     "Valid: ASCII and IDN names",
+			DNSNames: []string{"www.example.com", "www.xn--bcher-kva.de", "www.paypal.com"},
+			Subject:  []pkix.AttributeTypeAndValue{{Type: asn1.ObjectIdentifier{2, 5, 4, 10}, Value: "Bücher GmbH"}},
+		},
+		{
+			Name:     "Warning: homoglyph label",
+			DNSNames: []string{"www." + homoglyph + ".com"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'www." + homoglyph + ".com' label 'ехаmрlе' contains mixed scripts (Cyrillic, Latin)",
+				"Certificate subjectAltName 'www." + homoglyph + ".com' label 'ехаmрlе' is confusable with 'example' (UTS #39)",
+			},
+		},
+		{
+			Name:     "Warning: brand confusable",
+			DNSNames: []string{"www.paypa1.com", "rnicrosoft.example.com"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'www.paypa1.com' label 'paypa1' is confusable with 'paypal'",
+				"Certificate subjectAltName 'rnicrosoft.example.com' label 'rnicrosoft' is confusable with 'microsoft'",
+			},
+		},
+		{
+			Name: "Warning: invisible characters in subject",
+			Subject: []pkix.AttributeTypeAndValue{
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 10}, Value: "Example\u202e Inc"},
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "Sales\u200b"},
+			},
+			ExpectedErrors: []string{
+				"Subject attribute 2.5.4.10 'Example\u202e Inc' contains a bidirectional control character",
+				"Subject attribute 2.5.4.11 'Sales\u200b' contains a zero-width character",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{
+					Subject:  pkix.Name{Names: tc.Subject},
+					DNSNames: tc.DNSNames,
+				},
+			}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
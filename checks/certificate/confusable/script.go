@@ -0,0 +1,77 @@
+package confusable
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Script combinations that are not reported as mixed scripts, based on the
+// Highly Restrictive level of UTS #39 section 5.2
+var allowedScripts = [][]string{
+	{"Han", "Hiragana", "Katakana", "Latin"},
+	{"Bopomofo", "Han", "Latin"},
+	{"Hangul", "Han", "Latin"},
+}
+
+// scripts returns the sorted scripts used in a string, characters in the
+// Common and Inherited scripts are ignored.
+func scripts(s string) []string {
+	found := make(map[string]bool)
+	for _, r := range s {
+		if r < unicode.MaxASCII {
+			if unicode.IsLetter(r) {
+				found["Latin"] = true
+			}
+			continue
+		}
+		for name, table := range unicode.Scripts {
+			if name == "Common" || name == "Inherited" {
+				continue
+			}
+			if unicode.Is(table, r) {
+				found[name] = true
+				break
+			}
+		}
+	}
+
+	var list []string
+	for name := range found {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// mixedScripts returns the scripts of a string if it contains a combination
+// of scripts that is not allowed
+func mixedScripts(s string) (string, bool) {
+	list := scripts(s)
+	if len(list) < 2 {
+		return "", false
+	}
+
+	for _, allowed := range allowedScripts {
+		if subset(list, allowed) {
+			return "", false
+		}
+	}
+	return strings.Join(list, ", "), true
+}
+
+func subset(list, of []string) bool {
+	for _, s := range list {
+		var found bool
+		for _, o := range of {
+			if s == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package confusable
+
+import (
+	"strings"
+	"unicode"
+)
+
+// confusables maps characters to the Latin character they are visually
+// confusable with, a subset of the Unicode confusables.txt data (UTS #39)
+// for the scripts commonly used in spoofing.
+var confusables = map[rune]string{
+	// Cyrillic
+	'\u0430': "a", // CYRILLIC SMALL LETTER A
+	'\u0432': "b", // CYRILLIC SMALL LETTER VE
+	'\u0435': "e", // CYRILLIC SMALL LETTER IE
+	'\u0451': "e", // CYRILLIC SMALL LETTER IO
+	'\u04bb': "h", // CYRILLIC SMALL LETTER SHHA
+	'\u0456': "i", // CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u0457': "i", // CYRILLIC SMALL LETTER YI
+	'\u0458': "j", // CYRILLIC SMALL LETTER JE
+	'\u043a': "k", // CYRILLIC SMALL LETTER KA
+	'\u04cf': "l", // CYRILLIC SMALL LETTER PALOCHKA
+	'\u043c': "m", // CYRILLIC SMALL LETTER EM
+	'\u043d': "h", // CYRILLIC SMALL LETTER EN
+	'\u043e': "o", // CYRILLIC SMALL LETTER O
+	'\u0440': "p", // CYRILLIC SMALL LETTER ER
+	'\u051b': "q", // CYRILLIC SMALL LETTER QA
+	'\u0455': "s", // CYRILLIC SMALL LETTER DZE
+	'\u0442': "t", // CYRILLIC SMALL LETTER TE
+	'\u0443': "y", // CYRILLIC SMALL LETTER U
+	'\u04af': "y", // CYRILLIC SMALL LETTER STRAIGHT U
+	'\u0445': "x", // CYRILLIC SMALL LETTER HA
+	'\u051d': "w", // CYRILLIC SMALL LETTER WE
+	'\u0441': "c", // CYRILLIC SMALL LETTER ES
+	'\u0501': "d", // CYRILLIC SMALL LETTER KOMI DE
+	'\u0461': "w", // CYRILLIC SMALL LETTER OMEGA
+	'\u044a': "b", // CYRILLIC SMALL LETTER HARD SIGN
+	'\u044c': "b", // CYRILLIC SMALL LETTER SOFT SIGN
+
+	// Greek
+	'\u03b1': "a", // GREEK SMALL LETTER ALPHA
+	'\u03b2': "b", // GREEK SMALL LETTER BETA
+	'\u03b5': "e", // GREEK SMALL LETTER EPSILON
+	'\u03b7': "n", // GREEK SMALL LETTER ETA
+	'\u03b9': "i", // GREEK SMALL LETTER IOTA
+	'\u03ba': "k", // GREEK SMALL LETTER KAPPA
+	'\u03bd': "v", // GREEK SMALL LETTER NU
+	'\u03bf': "o", // GREEK SMALL LETTER OMICRON
+	'\u03c1': "p", // GREEK SMALL LETTER RHO
+	'\u03c4': "t", // GREEK SMALL LETTER TAU
+	'\u03c5': "u", // GREEK SMALL LETTER UPSILON
+	'\u03c7': "x", // GREEK SMALL LETTER CHI
+	'\u03b3': "y", // GREEK SMALL LETTER GAMMA
+	'\u03f2': "c", // GREEK LUNATE SIGMA SYMBOL
+	'\u03f3': "j", // GREEK LETTER YOT
+	'\u03c9': "w", // GREEK SMALL LETTER OMEGA
+
+	// Armenian
+	'\u0585': "o", // ARMENIAN SMALL LETTER OH
+	'\u0578': "n", // ARMENIAN SMALL LETTER VO
+	'\u057d': "u", // ARMENIAN SMALL LETTER SEH
+	'\u0570': "h", // ARMENIAN SMALL LETTER HO
+	'\u0581': "g", // ARMENIAN SMALL LETTER CO
+	'\u0566': "q", // ARMENIAN SMALL LETTER ZA
+
+	// Latin lookalikes
+	'\u0131': "i", // LATIN SMALL LETTER DOTLESS I
+	'\u0237': "j", // LATIN SMALL LETTER DOTLESS J
+	'\u2113': "l", // SCRIPT SMALL L
+	'\u0251': "a", // LATIN SMALL LETTER ALPHA
+	'\u0261': "g", // LATIN SMALL LETTER SCRIPT G
+	'\u0269': "i", // LATIN SMALL LETTER IOTA
+	'\u029f': "l", // LATIN LETTER SMALL CAPITAL L
+	'\u0274': "n", // LATIN LETTER SMALL CAPITAL N
+	'\u0280': "r", // LATIN LETTER SMALL CAPITAL R
+	'\ua731': "s", // LATIN LETTER SMALL CAPITAL S
+	'\u1d05': "d", // LATIN LETTER SMALL CAPITAL D
+	'\u1d07': "e", // LATIN LETTER SMALL CAPITAL E
+	'\u1d0b': "k", // LATIN LETTER SMALL CAPITAL K
+	'\u1d0d': "m", // LATIN LETTER SMALL CAPITAL M
+	'\u1d0f': "o", // LATIN LETTER SMALL CAPITAL O
+	'\u1d18': "p", // LATIN LETTER SMALL CAPITAL P
+	'\u1d1b': "t", // LATIN LETTER SMALL CAPITAL T
+	'\u1d1c': "u", // LATIN LETTER SMALL CAPITAL U
+	'\u1d20': "v", // LATIN LETTER SMALL CAPITAL V
+	'\u1d21': "w", // LATIN LETTER SMALL CAPITAL W
+	'\u1d22': "z", // LATIN LETTER SMALL CAPITAL Z
+
+	// ASCII
+	'0': "o",
+	'1': "l",
+	'|': "l",
+}
+
+// multiConfusables are ASCII sequences that are confusable with a single
+// character
+var multiConfusables = strings.NewReplacer("rn", "m", "vv", "w")
+
+// skeleton returns the lowercase skeleton of a string as described in UTS #39
+// section 4, characters are mapped to their prototype and default ignorable
+// characters are removed.
+func skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 0xff01 && r <= 0xff5e:
+			// Fullwidth ASCII variants
+			b.WriteRune(unicode.ToLower(r - 0xfee0))
+		case isZeroWidth(r) || isBidiControl(r):
+		default:
+			if c, ok := confusables[r]; ok {
+				b.WriteString(c)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return multiConfusables.Replace(b.String())
+}
+
+// isZeroWidth returns true for invisible characters
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u00ad', '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+		return true
+	}
+	return false
+}
+
+// isBidiControl returns true for the bidirectional embedding, override and
+// isolate characters
+func isBidiControl(r rune) bool {
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069') || r == '\u200e' || r == '\u200f'
+}
@@ -1,56 +1,82 @@
 package extkeyusage
 
 import (
-	"crypto/x509"
+	"encoding/asn1"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/keyusage/matrix"
 	"github.com/globalsign/certlint/errors"
 )
 
 const checkName = "Extended Key Usage Check"
 
+var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 37}
+
 func init() {
 	checks.RegisterCertificateCheck(checkName, nil, Check)
 }
 
 // Check verifies if the the required/allowed extended keyusages
-// are set in relation to the certificate type.
+// are set in relation to the certificate type as defined in the key usage
+// matrix.
 //
 // https://tools.ietf.org/html/rfc5280#section-4.2.1.12
 //
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	if len(d.Cert.ExtKeyUsage) == 0 && len(d.Cert.UnknownExtKeyUsage) == 0 {
-		e.Err("Certificate contains no extended key usage")
-		return e
+	ext, present := d.Extension(extensionOid)
+
+	rows := matrix.Match(d)
+	for _, r := range rows {
+		switch {
+		case r.ExtKeyUsage == matrix.Required && !present:
+			e.Err("Certificate contains no extended key usage")
+			return e
+		case r.ExtKeyUsage == matrix.Forbidden && present:
+			e.Err("Certificate contains an extended key usage extension")
+			return e
+		}
 	}
 
-	for _, ku := range d.Cert.ExtKeyUsage {
-		switch d.Type {
-		case "DV", "OV", "EV":
-			if ku != x509.ExtKeyUsageServerAuth && ku != x509.ExtKeyUsageClientAuth && ku != x509.ExtKeyUsageMicrosoftServerGatedCrypto {
-				e.Err("Certificate contains an extended key usage different from ServerAuth, ClientAuth or ServerGatedCrypto")
-				return e
-			}
-		case "PS":
-			if ku != x509.ExtKeyUsageClientAuth && ku != x509.ExtKeyUsageEmailProtection {
-				e.Err("Certificate contains an extended key usage different from ClientAuth or EmailProtection")
-				return e
+	var ekus []asn1.ObjectIdentifier
+	if present {
+		if _, err := asn1.Unmarshal(ext.Value, &ekus); err != nil {
+			e.Err("Certificate contains an invalid extended key usage extension")
+			return e
+		}
+	}
+
+	var required, forbidden []asn1.ObjectIdentifier
+	for _, r := range rows {
+		for _, oid := range r.RequiredExtKeyUsage {
+			if !contains(ekus, oid) && !contains(required, oid) {
+				required = append(required, oid)
 			}
-		case "CS":
-			if ku != x509.ExtKeyUsageCodeSigning {
-				e.Err("Certificate contains an extended key usage different from ClientAuth or EmailProtection")
-				return e
+		}
+		for _, oid := range ekus {
+			if r.ForbidsExtKeyUsage(oid) && !contains(forbidden, oid) {
+				forbidden = append(forbidden, oid)
 			}
 		}
 	}
 
-	if len(d.Cert.UnknownExtKeyUsage) > 0 {
-		// encryptedFileSystem 1.3.6.1.4.1.311.10.3.4
-		//return fmt.Errorf("%s Certificate contains an unknown extented key usage", d.Type)
+	for _, oid := range required {
+		e.Err("Certificate is missing extended key usage %s", matrix.ExtKeyUsageString(oid))
+	}
+	for _, oid := range forbidden {
+		e.Err("Certificate has extended key usage %s set", matrix.ExtKeyUsageString(oid))
 	}
 
 	return e
 }
+
+func contains(list []asn1.ObjectIdentifier, oid asn1.ObjectIdentifier) bool {
+	for _, l := range list {
+		if l.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package extkeyusage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks/certificate/keyusage/matrix"
+)
+
+// selfSigned returns a self-signed CA certificate without extended key usage
+func selfSigned(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func testData(t *testing.T, certType string, root bool, ekus []asn1.ObjectIdentifier) *certdata.Data {
+	spki, err := asn1.Marshal(certdata.SubjectPublicKeyInfo{
+		Algorithm:        pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}},
+		SubjectPublicKey: asn1.BitString{Bytes: make([]byte, 32), BitLength: 256},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &x509.Certificate{RawSubjectPublicKeyInfo: spki}
+	if root {
+		cert = selfSigned(t)
+	}
+	if ekus != nil {
+		b, err := asn1.Marshal(ekus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert.Extensions = []pkix.Extension{{Id: extensionOid, Value: b}}
+	}
+	return &certdata.Data{Cert: cert, Type: certType}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		Root           bool
+		ExtKeyUsage    []asn1.ObjectIdentifier
+		ExpectedErrors []string
+	}{
+		{
+			Name:        "Valid: DV",
+			Type:        "DV",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageServerAuth, matrix.ExtKeyUsageClientAuth},
+		},
+		{
+			Name: "Invalid: DV without extended key usage",
+			Type: "DV",
+			ExpectedErrors: []string{
+				"Certificate contains no extended key usage",
+			},
+		},
+		{
+			Name:        "Invalid: DV without ServerAuth",
+			Type:        "DV",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageClientAuth},
+			ExpectedErrors: []string{
+				"Certificate is missing extended key usage ServerAuth",
+			},
+		},
+		{
+			Name:        "Invalid: DV with CodeSigning",
+			Type:        "DV",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageServerAuth, matrix.ExtKeyUsageCodeSigning},
+			ExpectedErrors: []string{
+				"Certificate has extended key usage CodeSigning set",
+			},
+		},
+		{
+			Name: "Valid: root CA without extended key usage",
+			Type: "CA",
+			Root: true,
+		},
+		{
+			Name:        "Valid: subordinate CA",
+			Type:        "CA",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageServerAuth},
+		},
+		{
+			Name: "Invalid: subordinate CA without extended key usage",
+			Type: "CA",
+			ExpectedErrors: []string{
+				"Certificate contains no extended key usage",
+			},
+		},
+		{
+			Name:        "Valid: PS",
+			Type:        "PS",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageEmailProtection},
+		},
+		{
+			Name:        "Invalid: PS with ServerAuth",
+			Type:        "PS",
+			ExtKeyUsage: []asn1.ObjectIdentifier{matrix.ExtKeyUsageEmailProtection, matrix.ExtKeyUsageServerAuth},
+			ExpectedErrors: []string{
+				"Certificate has extended key usage ServerAuth set",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := testData(t, tc.Type, tc.Root, tc.ExtKeyUsage)
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,23 +1,50 @@
 package internal
 
 import (
-	"net"
 	"strings"
 
-	psl "golang.org/x/net/publicsuffix"
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
 )
 
-// All official domain suffixes are registered by icann, but because some
-// subdomains are not only check against the last part of the fqdn.
-func checkInternalName(fqdn string) bool {
-	if ip := net.ParseIP(fqdn); ip != nil {
-		return checkInternalIP(ip)
+// classifyName returns the classification of a domain name and the matching
+// special-use domain registry entry if any. All official domain suffixes are
+// registered by icann, but because some subdomains are not only check against
+// the last part of the fqdn.
+func classifyName(d *certdata.Data, fqdn string) (int, *domainRegistryEntry) {
+	name := strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	for i, entry := range domainRegistry {
+		if name == entry.Domain || strings.HasSuffix(name, "."+entry.Domain) {
+			return entry.Class, &domainRegistry[i]
+		}
 	}
 
-	suffix := strings.Split(strings.ToLower(fqdn), ".")
-	_, icann := psl.PublicSuffix(suffix[len(suffix)-1])
+	suffix := strings.Split(name, ".")
+	_, icann := d.PublicSuffix(suffix[len(suffix)-1])
 	if icann {
-		return false
+		return domainPublic, nil
+	}
+	return domainNotDelegated, nil
+}
+
+// checkInternalName reports domain names that can't be used in a publicly
+// trusted certificate, field describes where the name is found.
+func checkInternalName(e *errors.Errors, d *certdata.Data, field, fqdn string) {
+	class, entry := classifyName(d, fqdn)
+	switch class {
+	case domainReserved:
+		e.Err("%s '%s' contains a reserved domain name (%s)", field, fqdn, entry)
+	case domainInternal:
+		e.Err("%s '%s' contains an internal domain name (%s)", field, fqdn, entry)
+	case domainNotDelegated:
+		e.Err("%s '%s' contains a not yet delegated top-level domain", field, fqdn)
+	case domainOnion:
+		// Tor onion addresses are allowed as described in Appendix C
+		label := onionAddress(fqdn)
+		if isOnionV2(label) {
+			e.Err("%s '%s' contains a Tor v2 onion address (BR Appendix C)", field, fqdn)
+		} else if !validOnionV3(label) {
+			e.Err("%s '%s' contains an invalid Tor v3 onion address (BR Appendix C)", field, fqdn)
+		}
 	}
-	return true
 }
@@ -0,0 +1,42 @@
+package internal
+
+// Classification of special-use and internal domain names
+const (
+	domainPublic = iota
+	domainReserved
+	domainInternal
+	domainNotDelegated
+	domainOnion
+)
+
+// domainRegistry contains the special-use domain names that can't be included
+// in a publicly trusted certificate, except for .onion which is handled as
+// described in Appendix C of the Baseline Requirements.
+//
+// https://www.iana.org/assignments/special-use-domain-names
+//
+var domainRegistry = []domainRegistryEntry{
+	{"localhost", "RFC 6761", domainReserved},
+	{"test", "RFC 6761", domainReserved},
+	{"invalid", "RFC 6761", domainReserved},
+	{"example", "RFC 6761", domainReserved},
+	{"alt", "RFC 9476", domainReserved},
+	{"local", "RFC 6762", domainInternal},
+	{"home.arpa", "RFC 8375", domainInternal},
+	{"internal", "ICANN Resolution 2024.07.29.06", domainInternal},
+	{"corp", "ICANN Resolution 2018.02.04.12", domainInternal},
+	{"home", "ICANN Resolution 2018.02.04.12", domainInternal},
+	{"mail", "ICANN Resolution 2018.02.04.12", domainInternal},
+	{"onion", "RFC 7686", domainOnion},
+}
+
+// domainRegistryEntry is a special-use domain name
+type domainRegistryEntry struct {
+	Domain string // domain name, including all subdomains
+	RFC    string // reference
+	Class  int    // classification of the domain name
+}
+
+func (e *domainRegistryEntry) String() string {
+	return "." + e.Domain + ", " + e.RFC
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"net"
+
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
 	"github.com/globalsign/certlint/errors"
@@ -20,24 +22,37 @@ func init() {
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	if checkInternalName(d.Cert.Subject.CommonName) {
-		e.Err("Certificate contains an internal server name in the common name '%s'", d.Cert.Subject.CommonName)
+	if ip := net.ParseIP(d.Cert.Subject.CommonName); ip != nil {
+		checkReservedIP(e, "Certificate common name", ip)
+	} else if len(d.Cert.Subject.CommonName) > 0 {
+		checkInternalName(e, d, "Certificate common name", d.Cert.Subject.CommonName)
 	}
 	for _, n := range d.Cert.DNSNames {
-		if checkInternalName(n) {
-			e.Err("Certificate subjectAltName '%s' contains an internal server name", n)
+		if net.ParseIP(n) != nil {
+			e.Err("Certificate subjectAltName '%s' contains an IP address in a dNSName, which MUST be an iPAddress", n)
+			continue
 		}
+		checkInternalName(e, d, "Certificate subjectAltName", n)
 	}
+	checkOnion(e, d)
 
-	// Check for internal IP addresses
+	// Check for reserved IP addresses (BR 7.1.2.7.12)
 	for _, ip := range d.Cert.IPAddresses {
-		if !ip.IsGlobalUnicast() {
-			e.Err("Certificate subjectAltName '%v' contains a non global unicast IP address", ip)
-		}
-		if checkInternalIP(ip) {
-			e.Err("Certificate subjectAltName '%v' contains a private or local IP address", ip)
-		}
+		checkReservedIP(e, "Certificate subjectAltName", ip)
 	}
 
 	return e
 }
+
+// checkReservedIP reports an IP address in a reserved range, including the
+// embedded IPv4 address if that was the reserved address
+func checkReservedIP(e *errors.Errors, field string, ip net.IP) {
+	entry, embedded := checkInternalIP(ip)
+	switch {
+	case entry == nil:
+	case embedded != nil:
+		e.Err("%s '%s' (embedding '%s') contains a reserved IP address (%s)", field, ip, embedded, entry)
+	default:
+		e.Err("%s '%s' contains a reserved IP address (%s)", field, ip, entry)
+	}
+}
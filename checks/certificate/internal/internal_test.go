@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
 )
 
 func TestInternal(t *testing.T) {
@@ -30,3 +32,185 @@ func TestInternal(t *testing.T) {
 		t.Errorf("Expected 9 errors, got %d", len(e.List()))
 	}
 }
+
+func TestCheckInternalIP(t *testing.T) {
+	testCases := []struct {
+		IP       string
+		Entry    string
+		Embedded string
+	}{
+		{"8.8.8.8", "", ""},
+		{"100.64.1.1", "Shared Address Space", ""},
+		{"127.0.0.1", "Loopback", ""},
+		{"192.0.0.8", "IPv4 dummy address", ""},
+		{"192.0.0.9", "", ""},
+		{"198.51.100.7", "Documentation (TEST-NET-2)", ""},
+		{"239.1.1.1", "Multicast", ""},
+		{"2001:4860:4860::8888", "", ""},
+		{"fd12:3456::1", "Unique-Local", ""},
+		{"2001:db8::1", "Documentation", ""},
+		{"2002:c0a8:0101::1", "Private-Use", "192.168.1.1"},
+		{"2001:0:4136:e378:8000:63bf:f5fe:fefe", "Private-Use", "10.1.1.1"},
+		{"2002:0808:0808::1", "", ""},
+	}
+
+	for _, tc := range testCases {
+		entry, embedded := checkInternalIP(net.ParseIP(tc.IP))
+		switch {
+		case entry == nil && tc.Entry != "":
+			t.Errorf("%s: expected %q, got no registry entry", tc.IP, tc.Entry)
+		case entry != nil && entry.Name != tc.Entry:
+			t.Errorf("%s: expected %q, got %q", tc.IP, tc.Entry, entry.Name)
+		case tc.Embedded != "" && !embedded.Equal(net.ParseIP(tc.Embedded)):
+			t.Errorf("%s: expected embedded address %s, got %v", tc.IP, tc.Embedded, embedded)
+		}
+	}
+}
+
+func TestCheckInternalName(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{"www.example.com", ""},
+		{"printer.home.arpa", "Certificate subjectAltName 'printer.home.arpa' contains an internal domain name (.home.arpa, RFC 8375)"},
+		{"www.test", "Certificate subjectAltName 'www.test' contains a reserved domain name (.test, RFC 6761)"},
+		{"intranet.server", "Certificate subjectAltName 'intranet.server' contains a not yet delegated top-level domain"},
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", ""},
+		{"www.duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", ""},
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion", "Certificate subjectAltName 'duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion' contains an invalid Tor v3 onion address (BR Appendix C)"},
+		{"3g2upl4pq6kufc4m.onion", "Certificate subjectAltName '3g2upl4pq6kufc4m.onion' contains a Tor v2 onion address (BR Appendix C)"},
+	}
+
+	for _, tc := range testCases {
+		e := errors.New(nil)
+		checkInternalName(e, &certdata.Data{}, "Certificate subjectAltName", tc.Name)
+
+		var msg string
+		if list := e.List(); len(list) > 0 {
+			msg = list[0].Error()
+		}
+		if msg != tc.Expected {
+			t.Errorf("%s: expected %q, got %q", tc.Name, tc.Expected, msg)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	onion := "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+	issued := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	beforeAppendixC := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Name           string
+		CertType       string
+		NotBefore      time.Time
+		NotAfter       time.Time
+		DNSNames       []string
+		IPAddresses    []string
+		ExpectedErrors []string
+	}{
+		{
+			Name:      "Valid: DV onion domain name",
+			CertType:  "DV",
+			NotBefore: issued,
+			NotAfter:  issued.AddDate(0, 0, 90),
+			DNSNames:  []string{onion, "www." + onion},
+		},
+		{
+			Name:      "Valid: EV onion domain name before BR Appendix C",
+			CertType:  "EV",
+			NotBefore: beforeAppendixC,
+			NotAfter:  beforeAppendixC.AddDate(0, 12, 0),
+			DNSNames:  []string{onion},
+		},
+		{
+			Name:      "Invalid: OV onion domain name before BR Appendix C",
+			CertType:  "OV",
+			NotBefore: beforeAppendixC,
+			NotAfter:  beforeAppendixC.AddDate(0, 12, 0),
+			DNSNames:  []string{onion},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '" + onion + "' contains an onion domain name, which is only allowed in EV certificates (EV Guidelines Appendix F)",
+			},
+		},
+		{
+			Name:      "Invalid: DV wildcard onion domain name",
+			CertType:  "DV",
+			NotBefore: issued,
+			NotAfter:  issued.AddDate(0, 0, 90),
+			DNSNames:  []string{"*." + onion},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '*." + onion + "' contains a wildcard onion domain name, which MUST be validated in accordance with EV Guidelines Appendix F (BR Appendix C)",
+			},
+		},
+		{
+			Name:      "Invalid: EV onion domain name longer than 15 months",
+			CertType:  "EV",
+			NotBefore: beforeAppendixC,
+			NotAfter:  beforeAppendixC.AddDate(0, 15, 0),
+			DNSNames:  []string{onion},
+			ExpectedErrors: []string{
+				"EV Certificate with an onion domain name MUST NOT have a validity period longer than 15 months (EV Guidelines Appendix F)",
+			},
+		},
+		{
+			Name:      "Invalid: IP address in a dNSName",
+			CertType:  "DV",
+			NotBefore: issued,
+			NotAfter:  issued.AddDate(0, 0, 90),
+			DNSNames:  []string{"203.0.113.10"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '203.0.113.10' contains an IP address in a dNSName, which MUST be an iPAddress",
+			},
+		},
+		{
+			Name:        "Invalid: reserved IP address",
+			CertType:    "DV",
+			NotBefore:   issued,
+			NotAfter:    issued.AddDate(0, 0, 90),
+			IPAddresses: []string{"8.8.8.8", "10.1.1.1"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '10.1.1.1' contains a reserved IP address (Private-Use, RFC 1918)",
+			},
+		},
+		{
+			Name:        "Invalid: IPv6 address embedding a reserved IPv4 address",
+			CertType:    "DV",
+			NotBefore:   issued,
+			NotAfter:    issued.AddDate(0, 0, 90),
+			IPAddresses: []string{"2002:c0a8:101::1"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '2002:c0a8:101::1' (embedding '192.168.1.1') contains a reserved IP address (Private-Use, RFC 1918)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Type: tc.CertType,
+				Cert: &x509.Certificate{
+					NotBefore: tc.NotBefore,
+					NotAfter:  tc.NotAfter,
+					DNSNames:  tc.DNSNames,
+				},
+			}
+			for _, ip := range tc.IPAddresses {
+				d.Cert.IPAddresses = append(d.Cert.IPAddresses, net.ParseIP(ip))
+			}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
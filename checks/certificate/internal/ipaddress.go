@@ -1,32 +1,97 @@
 package internal
 
-import "net"
-
-// checkInternalIP verifies is an IP address in a registered internal range.
-// TODO: Check if we need to verify any IPv6 ranges
-// TODO: We should also check for special purpose IP ranges, we might want to do that in a specific function
-func checkInternalIP(ip net.IP) bool {
-	var privIPSpace []net.IPNet
-	// 10.0.0.0/8
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xa, 0x0, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0x0, 0x0, 0x0},
-	})
-	// 172.16.0.0/12"
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xac, 0x10, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0xf0, 0x0, 0x0},
-	})
-	// 192.168.0.0/16
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xc0, 0xa8, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0xff, 0x0, 0x0},
-	})
-
-	for _, ipSpace := range privIPSpace {
-		if ipSpace.Contains(ip) {
-			return true
+import (
+	"fmt"
+	"net"
+)
+
+// ipRegistryEntry is a special-purpose address block
+type ipRegistryEntry struct {
+	Block  string // address block in CIDR notation
+	Name   string // name of the address block in the registry
+	RFC    string // reference
+	Global bool   // globally reachable
+}
+
+// ipNetworks contains the parsed address blocks of the ipRegistry
+var ipNetworks []*net.IPNet
+
+func (e *ipRegistryEntry) String() string {
+	return fmt.Sprintf("%s, %s", e.Name, e.RFC)
+}
+
+func init() {
+	for _, entry := range ipRegistry {
+		ipNetworks = append(ipNetworks, mustParseCIDR(entry.Block))
+	}
+}
+
+// lookupIP returns the most specific special-purpose address block the IP
+// address is part of, nil is returned for other IP addresses.
+func lookupIP(ip net.IP) *ipRegistryEntry {
+	var match *ipRegistryEntry
+	var matchSize int
+
+	// IPv4 addresses are only compared against IPv4 address blocks
+	isIPv4 := ip.To4() != nil
+	for i, network := range ipNetworks {
+		if (network.IP.To4() != nil) != isIPv4 || !network.Contains(ip) {
+			continue
+		}
+		if size, _ := network.Mask.Size(); match == nil || size > matchSize {
+			match, matchSize = &ipRegistry[i], size
+		}
+	}
+	return match
+}
+
+// checkInternalIP verifies if an IP address is part of a special-purpose
+// address block that is not globally reachable. The matching registry entry is
+// returned, for IPv6 addresses that embed an IPv4 address (6to4, Teredo and
+// NAT64) the embedded address is verified as well.
+func checkInternalIP(ip net.IP) (*ipRegistryEntry, net.IP) {
+	entry := lookupIP(ip)
+	if entry != nil && !entry.Global {
+		return entry, nil
+	}
+
+	if embedded := embeddedIPv4(ip); embedded != nil {
+		if entry = lookupIP(embedded); entry != nil && !entry.Global {
+			return entry, embedded
 		}
 	}
-	return false
+	return nil, nil
+}
+
+var (
+	sixToFour = mustParseCIDR("2002::/16")
+	teredo    = mustParseCIDR("2001::/32")
+	nat64     = mustParseCIDR("64:ff9b::/96")
+)
+
+// embeddedIPv4 returns the IPv4 address embedded in a 6to4 (RFC 3056), Teredo
+// client (RFC 4380) or NAT64 (RFC 6052) IPv6 address.
+func embeddedIPv4(ip net.IP) net.IP {
+	if ip.To4() != nil || len(ip) != net.IPv6len {
+		return nil
+	}
+
+	switch {
+	case sixToFour.Contains(ip):
+		return net.IPv4(ip[2], ip[3], ip[4], ip[5])
+	case teredo.Contains(ip):
+		// the client address is obfuscated by inverting all bits
+		return net.IPv4(^ip[12], ^ip[13], ^ip[14], ^ip[15])
+	case nat64.Contains(ip):
+		return net.IPv4(ip[12], ip[13], ip[14], ip[15])
+	}
+	return nil
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
 }
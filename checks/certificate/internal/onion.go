@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Tor onion service v3 address as defined in rend-spec-v3 section 6:
+//
+//  onion_address = base32(PUBKEY | CHECKSUM | VERSION) + ".onion"
+//  CHECKSUM = H(".onion checksum" | PUBKEY | VERSION)[:2]
+//
+const (
+	onionV2Length      = 16
+	onionV3Length      = 56
+	onionV3Version     = 0x03
+	onionChecksumLabel = ".onion checksum"
+)
+
+var onionEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Onion domain names are allowed in DV, OV and IV certificates since Appendix C
+// of the Baseline Requirements, before only in EV certificates (EV Guidelines
+// Appendix F).
+var brAppendixC = checks.Rule{
+	Name:      "Ballot SC27",
+	Effective: time.Date(2020, 3, 19, 0, 0, 0, 0, time.UTC),
+}
+
+// Maximum validity period of an EV certificate with an onion domain name (EV
+// Guidelines Appendix F)
+const onionEVMonths = 15
+
+// onionAddress returns the onion service label of a fqdn ending with .onion,
+// subdomains of the onion service are allowed.
+func onionAddress(fqdn string) string {
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(fqdn), "."), ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	return labels[len(labels)-2]
+}
+
+// isOnionV2 returns true if the label has the size of a deprecated Tor v2
+// onion service address
+func isOnionV2(label string) bool {
+	return len(label) == onionV2Length
+}
+
+// validOnionV3 verifies the version and checksum of a Tor v3 onion service
+// address as required by Appendix C of the Baseline Requirements.
+func validOnionV3(label string) bool {
+	if len(label) != onionV3Length {
+		return false
+	}
+
+	b, err := onionEncoding.DecodeString(strings.ToUpper(label))
+	if err != nil || len(b) != 35 {
+		return false
+	}
+
+	pubkey, checksum, version := b[:32], b[32:34], b[34]
+	if version != onionV3Version {
+		return false
+	}
+
+	h := sha3.New256()
+	h.Write([]byte(onionChecksumLabel))
+	h.Write(pubkey)
+	h.Write([]byte{version})
+	return bytes.Equal(h.Sum(nil)[:2], checksum)
+}
+
+// checkOnion verifies the onion domain names in the certificate against the
+// requirements of the certificate type, the syntax of each address is verified
+// by checkInternalName.
+func checkOnion(e *errors.Errors, d *certdata.Data) {
+	var found bool
+	for _, n := range onionNames(d) {
+		found = true
+		if d.Type == "EV" {
+			continue
+		}
+
+		if !brAppendixC.Applies(d) {
+			e.Err("Certificate subjectAltName '%s' contains an onion domain name, which is only allowed in EV certificates (EV Guidelines Appendix F)", n)
+			continue
+		}
+
+		// A wildcard can't be validated over the onion service itself
+		if strings.HasPrefix(n, "*.") {
+			e.Warning("Certificate subjectAltName '%s' contains a wildcard onion domain name, which MUST be validated in accordance with EV Guidelines Appendix F (BR Appendix C)", n)
+		}
+	}
+
+	// The inclusive validity period of an EV certificate
+	if found && d.Type == "EV" && d.Cert.NotAfter.Add(time.Second).After(d.Cert.NotBefore.AddDate(0, onionEVMonths, 0)) {
+		e.Err("EV Certificate with an onion domain name MUST NOT have a validity period longer than %d months (EV Guidelines Appendix F)", onionEVMonths)
+	}
+}
+
+// onionNames returns the dNSNames that end with .onion
+func onionNames(d *certdata.Data) []string {
+	var names []string
+	for _, n := range d.Cert.DNSNames {
+		if strings.HasSuffix(strings.TrimSuffix(strings.ToLower(n), "."), ".onion") {
+			names = append(names, n)
+		}
+	}
+	return names
+}
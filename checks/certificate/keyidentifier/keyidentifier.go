@@ -0,0 +1,45 @@
+package keyidentifier
+
+import (
+	"encoding/asn1"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+)
+
+const checkName = "Key Identifier Check"
+
+var (
+	authorityKeyIDOid = asn1.ObjectIdentifier{2, 5, 29, 35}
+	subjectKeyIDOid   = asn1.ObjectIdentifier{2, 5, 29, 14}
+)
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, nil, Check)
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	_, hasAuthorityKeyID := d.Extension(authorityKeyIDOid)
+	_, hasSubjectKeyID := d.Extension(subjectKeyIDOid)
+
+	// RFC: The keyIdentifier field of the authorityKeyIdentifier extension MUST
+	// be included in all certificates generated by conforming CAs to
+	// facilitate certification path construction. There is one exception;
+	// where a CA distributes its public key in the form of a "self-signed"
+	// certificate, the authority key identifier MAY be omitted.
+	if !hasAuthorityKeyID && !d.SelfSigned() {
+		e.Err("Certificate contains no AuthorityKeyId extension (RFC 5280 4.2.1.1)")
+	}
+
+	// RFC: To facilitate certification path construction, this extension MUST
+	// appear in all conforming CA certificates.
+	if d.Cert.IsCA && !hasSubjectKeyID {
+		e.Err("CA certificate contains no SubjectKeyId extension (RFC 5280 4.2.1.2)")
+	}
+
+	return e
+}
@@ -0,0 +1,97 @@
+package keyidentifier
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// selfSigned returns a self-signed CA certificate, it contains a
+// SubjectKeyId but no AuthorityKeyId extension
+func selfSigned(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCheck(t *testing.T) {
+	aki := pkix.Extension{Id: authorityKeyIDOid}
+	ski := pkix.Extension{Id: subjectKeyIDOid}
+
+	testCases := []struct {
+		Name           string
+		Cert           *x509.Certificate
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: end entity with AuthorityKeyId",
+			Cert: &x509.Certificate{Extensions: []pkix.Extension{aki}},
+		},
+		{
+			Name: "Valid: CA with AuthorityKeyId and SubjectKeyId",
+			Cert: &x509.Certificate{IsCA: true, Extensions: []pkix.Extension{aki, ski}},
+		},
+		{
+			Name: "Valid: self-signed CA without AuthorityKeyId",
+			Cert: selfSigned(t),
+		},
+		{
+			Name: "Invalid: end entity without AuthorityKeyId",
+			Cert: &x509.Certificate{RawIssuer: []byte{1}, RawSubject: []byte{2}},
+			ExpectedErrors: []string{
+				"Certificate contains no AuthorityKeyId extension (RFC 5280 4.2.1.1)",
+			},
+		},
+		{
+			Name: "Invalid: CA without SubjectKeyId",
+			Cert: &x509.Certificate{IsCA: true, Extensions: []pkix.Extension{aki}},
+			ExpectedErrors: []string{
+				"CA certificate contains no SubjectKeyId extension (RFC 5280 4.2.1.2)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{Cert: tc.Cert}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,81 +1,72 @@
 package keyusage
 
 import (
-	"crypto/dsa"
-	"crypto/ecdsa"
-	"crypto/rsa"
 	"crypto/x509"
+	"encoding/asn1"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/keyusage/matrix"
 	"github.com/globalsign/certlint/errors"
 )
 
 const checkName = "Key Usage Check"
 
+var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 15}
+
 func init() {
 	checks.RegisterCertificateCheck(checkName, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 // checkKeyUsageExtension verifies if the the required/allowed keyusages are set
+// for the certificate type and key algorithm as defined in the key usage matrix.
 //
 // https://tools.ietf.org/html/rfc5280#section-4.2.1.3
 //
-// TODO: Check if we can or need to do something with dh.PublicKey
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
-	var forbidden []x509.KeyUsage
+	var required, forbidden, oneOf x509.KeyUsage
+
+	_, present := d.Extension(extensionOid)
 
-	// Source
-	// https://github.com/awslabs/certlint/blob/master/lib/certlint/extensions/keyusage.rb
-	switch d.Cert.PublicKey.(type) {
-	case *rsa.PublicKey:
-		forbidden = []x509.KeyUsage{
-			x509.KeyUsageKeyAgreement,
-			x509.KeyUsageEncipherOnly,
-			x509.KeyUsageDecipherOnly,
+	rows := matrix.Match(d)
+	for _, r := range rows {
+		switch {
+		case r.KeyUsage == matrix.Required && !present:
+			e.Err("Certificate has no key usage set")
+			return e
+		case r.KeyUsage == matrix.Forbidden && present:
+			e.Err("Certificate contains a key usage extension")
+			return e
 		}
-	case *ecdsa.PublicKey:
-		forbidden = []x509.KeyUsage{
-			x509.KeyUsageKeyEncipherment,
-			x509.KeyUsageDataEncipherment,
+	}
+
+	for _, r := range rows {
+		required |= r.RequiredKeyUsage
+		for ku := x509.KeyUsageDigitalSignature; ku <= x509.KeyUsageDecipherOnly; ku <<= 1 {
+			if r.ForbidsKeyUsage(ku) {
+				forbidden |= ku
+			}
 		}
-	case *dsa.PublicKey:
-		forbidden = []x509.KeyUsage{
-			x509.KeyUsageKeyEncipherment,
-			x509.KeyUsageDataEncipherment,
-			x509.KeyUsageKeyAgreement,
-			x509.KeyUsageEncipherOnly,
-			x509.KeyUsageDecipherOnly,
+
+		// report every set of alternatives only once
+		if r.OneOfKeyUsage != 0 && d.Cert.KeyUsage&r.OneOfKeyUsage == 0 && oneOf&r.OneOfKeyUsage != r.OneOfKeyUsage {
+			e.Err("Certificate has none of the key usages %s set", keyUsagesString(r.OneOfKeyUsage))
+			oneOf |= r.OneOfKeyUsage
 		}
-		// case *dh.PublicKey:
-		// forbidden = []x509.KeyUsage{
-		//   x509.KeyUsageDigitalSignature,
-		//   x509.KeyUsageContentCommitment,
-		//   x509.KeyUsageKeyEncipherment,
-		//   x509.KeyUsageDataEncipherment,
-		//   x509.KeyUsageCertSign,
-		//   x509.KeyUsageCRLSign,
-		// }
 	}
 
-	// If we have not defined this certificate as a CA certificate, the following
-	// key usages would not be allowed
-	if d.Type != "CA" {
-		if d.Cert.KeyUsage == 0 {
-			e.Err("Certificate has no key usage set")
-			return e
+	for ku := x509.KeyUsageDigitalSignature; ku <= x509.KeyUsageDecipherOnly; ku <<= 1 {
+		if required&ku != 0 && d.Cert.KeyUsage&ku == 0 {
+			e.Err("Certificate is missing key usage %s", keyUsageString(ku))
 		}
-
-		forbidden = append(forbidden, x509.KeyUsageCertSign)
-		forbidden = append(forbidden, x509.KeyUsageCRLSign)
 	}
 
 	// Check if there are any forbidden key usages set
-	for _, fku := range forbidden {
-		if d.Cert.KeyUsage&fku != 0 {
-			e.Err("Certificate has key usage %s set", keyUsageString(fku))
+	for ku := x509.KeyUsageDigitalSignature; ku <= x509.KeyUsageDecipherOnly; ku <<= 1 {
+		if forbidden&ku != 0 && d.Cert.KeyUsage&ku != 0 {
+			e.Err("Certificate has key usage %s set", keyUsageString(ku))
 		}
 	}
 
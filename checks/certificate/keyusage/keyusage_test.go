@@ -0,0 +1,139 @@
+package keyusage
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+var (
+	rsaKey   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	ecdsaKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+)
+
+func testData(t *testing.T, certType string, keyAlgorithm asn1.ObjectIdentifier, ku x509.KeyUsage) *certdata.Data {
+	spki, err := asn1.Marshal(certdata.SubjectPublicKeyInfo{
+		Algorithm:        pkix.AlgorithmIdentifier{Algorithm: keyAlgorithm},
+		SubjectPublicKey: asn1.BitString{Bytes: make([]byte, 32), BitLength: 256},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &x509.Certificate{RawSubjectPublicKeyInfo: spki, KeyUsage: ku}
+	if ku != 0 {
+		cert.Extensions = []pkix.Extension{{Id: extensionOid, Critical: true}}
+	}
+	return &certdata.Data{Cert: cert, Type: certType}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		KeyAlgorithm   asn1.ObjectIdentifier
+		KeyUsage       x509.KeyUsage
+		ExpectedErrors []string
+	}{
+		{
+			Name:         "Valid: DV RSA",
+			Type:         "DV",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		},
+		{
+			Name:         "Invalid: DV without key usage",
+			Type:         "DV",
+			KeyAlgorithm: rsaKey,
+			ExpectedErrors: []string{
+				"Certificate has no key usage set",
+			},
+		},
+		{
+			Name:         "Invalid: DV with CertSign",
+			Type:         "DV",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			ExpectedErrors: []string{
+				"Certificate has key usage CertSign set",
+			},
+		},
+		{
+			Name:         "Invalid: DV ECDSA with KeyEncipherment",
+			Type:         "DV",
+			KeyAlgorithm: ecdsaKey,
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExpectedErrors: []string{
+				"Certificate has key usage KeyEncipherment set",
+			},
+		},
+		{
+			Name:         "Valid: CA",
+			Type:         "CA",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		},
+		{
+			Name:         "Invalid: CA without CRLSign",
+			Type:         "CA",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageCertSign,
+			ExpectedErrors: []string{
+				"Certificate is missing key usage CRLSign",
+			},
+		},
+		{
+			Name:         "Invalid: CA without key usage",
+			Type:         "CA",
+			KeyAlgorithm: ecdsaKey,
+			ExpectedErrors: []string{
+				"Certificate has no key usage set",
+			},
+		},
+		{
+			Name:         "Valid: PS RSA",
+			Type:         "PS",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		},
+		{
+			Name:         "Invalid: PS RSA without signing or key management",
+			Type:         "PS",
+			KeyAlgorithm: rsaKey,
+			KeyUsage:     x509.KeyUsageContentCommitment,
+			ExpectedErrors: []string{
+				"Certificate has none of the key usages DigitalSignature, KeyEncipherment set",
+			},
+		},
+		{
+			Name:         "Invalid: PS ECDSA with KeyEncipherment",
+			Type:         "PS",
+			KeyAlgorithm: ecdsaKey,
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExpectedErrors: []string{
+				"Certificate has key usage KeyEncipherment set",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := testData(t, tc.Type, tc.KeyAlgorithm, tc.KeyUsage)
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
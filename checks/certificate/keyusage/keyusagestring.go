@@ -1,6 +1,9 @@
 package keyusage
 
-import "crypto/x509"
+import (
+	"crypto/x509"
+	"strings"
+)
 
 // keyUsageString returns the name of the keyusage as string
 func keyUsageString(ku x509.KeyUsage) string {
@@ -26,3 +29,14 @@ func keyUsageString(ku x509.KeyUsage) string {
 	}
 	return "Unknown"
 }
+
+// keyUsagesString returns the names of all key usages as string
+func keyUsagesString(ku x509.KeyUsage) string {
+	var names []string
+	for bit := x509.KeyUsageDigitalSignature; bit <= x509.KeyUsageDecipherOnly; bit <<= 1 {
+		if ku&bit != 0 {
+			names = append(names, keyUsageString(bit))
+		}
+	}
+	return strings.Join(names, ", ")
+}
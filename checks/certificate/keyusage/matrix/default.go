@@ -0,0 +1,196 @@
+package matrix
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// Extended key usages used in the default matrix
+var (
+	ExtKeyUsageAny                        = asn1.ObjectIdentifier{2, 5, 29, 37, 0}
+	ExtKeyUsageServerAuth                 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1}
+	ExtKeyUsageClientAuth                 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}
+	ExtKeyUsageCodeSigning                = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 3}
+	ExtKeyUsageEmailProtection            = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 4}
+	ExtKeyUsageTimeStamping               = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+	ExtKeyUsageOCSPSigning                = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 9}
+	ExtKeyUsageMicrosoftServerGatedCrypto = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 10, 3, 3}
+	ExtKeyUsageNetscapeServerGatedCrypto  = asn1.ObjectIdentifier{2, 16, 840, 1, 113730, 4, 1}
+)
+
+var extKeyUsageNames = []struct {
+	oid  asn1.ObjectIdentifier
+	name string
+}{
+	{ExtKeyUsageAny, "Any"},
+	{ExtKeyUsageServerAuth, "ServerAuth"},
+	{ExtKeyUsageClientAuth, "ClientAuth"},
+	{ExtKeyUsageCodeSigning, "CodeSigning"},
+	{ExtKeyUsageEmailProtection, "EmailProtection"},
+	{ExtKeyUsageTimeStamping, "TimeStamping"},
+	{ExtKeyUsageOCSPSigning, "OCSPSigning"},
+	{ExtKeyUsageMicrosoftServerGatedCrypto, "MicrosoftServerGatedCrypto"},
+	{ExtKeyUsageNetscapeServerGatedCrypto, "NetscapeServerGatedCrypto"},
+}
+
+// ExtKeyUsageString returns the name of a known extended key usage or the
+// dotted OID otherwise
+func ExtKeyUsageString(oid asn1.ObjectIdentifier) string {
+	for _, n := range extKeyUsageNames {
+		if n.oid.Equal(oid) {
+			return n.name
+		}
+	}
+	return oid.String()
+}
+
+var (
+	tlsTypes = []string{"DV", "OV", "EV", "IV"}
+	eeTypes  = []string{"DV", "OV", "EV", "IV", "PS", "CS", "EVCS", "TS", "OCSP", "IPSEC"}
+)
+
+// Default returns the default key usage matrix
+func Default() []Row {
+	return []Row{
+		// RFC 5280 4.2.1.3: When present, conforming CAs SHOULD mark this
+		// extension as critical.
+		{KeyUsageCritical: Required},
+
+		// Key usages that can't be used with the key algorithm
+		// https://github.com/awslabs/certlint/blob/master/lib/certlint/extensions/keyusage.rb
+		{
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmRSA},
+			ForbiddenKeyUsage: x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+		},
+		{
+			// RFC 4055: keys restricted to RSASSA-PSS can only sign
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmRSAPSS},
+			ForbiddenKeyUsage: x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+		},
+		{
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmECDSA},
+			ForbiddenKeyUsage: x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment,
+		},
+		{
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmDSA},
+			ForbiddenKeyUsage: x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+		},
+		{
+			// RFC 8410 5: signature keys
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmEd25519, certdata.KeyAlgorithmEd448},
+			ForbiddenKeyUsage: x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+		},
+		{
+			// RFC 8410 5: key agreement keys, the keyAgreement bit MUST be set
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmX25519, certdata.KeyAlgorithmX448},
+			RequiredKeyUsage:  x509.KeyUsageKeyAgreement,
+			AllowedKeyUsage:   x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+			ExclusiveKeyUsage: true,
+		},
+		{
+			// ML-DSA keys can only be used for signatures
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmMLDSA44, certdata.KeyAlgorithmMLDSA65, certdata.KeyAlgorithmMLDSA87},
+			ForbiddenKeyUsage: x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+		},
+		{
+			// ML-KEM keys can only be used for key encapsulation, the
+			// keyEncipherment bit MUST be the only key usage set
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmMLKEM512, certdata.KeyAlgorithmMLKEM768, certdata.KeyAlgorithmMLKEM1024},
+			RequiredKeyUsage:  x509.KeyUsageKeyEncipherment,
+			ExclusiveKeyUsage: true,
+		},
+
+		// CA certificates
+		{
+			Types:            []string{"CA"},
+			KeyUsage:         Required,
+			RequiredKeyUsage: x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		},
+		{
+			// BR 7.1.2.10.6: subordinate CA certificates MUST contain an
+			// extended key usage extension, the type of the certificates they
+			// issue is not known here.
+			Types:       []string{"CA"},
+			SelfSigned:  boolean(false),
+			ExtKeyUsage: Required,
+		},
+
+		// End entity certificates
+		{
+			Types:             eeTypes,
+			KeyUsage:          Required,
+			ForbiddenKeyUsage: x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			ExtKeyUsage:       Required,
+		},
+
+		// TLS server certificates (BR 7.1.2.7.10 and 7.1.2.7.11)
+		{
+			Types:                tlsTypes,
+			RequiredExtKeyUsage:  []asn1.ObjectIdentifier{ExtKeyUsageServerAuth},
+			AllowedExtKeyUsage:   []asn1.ObjectIdentifier{ExtKeyUsageClientAuth, ExtKeyUsageMicrosoftServerGatedCrypto},
+			ExclusiveExtKeyUsage: true,
+		},
+		{
+			Types:             tlsTypes,
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmRSA},
+			OneOfKeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExclusiveKeyUsage: true,
+		},
+		{
+			Types:             tlsTypes,
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmECDSA},
+			RequiredKeyUsage:  x509.KeyUsageDigitalSignature,
+			AllowedKeyUsage:   x509.KeyUsageKeyAgreement,
+			ExclusiveKeyUsage: true,
+		},
+
+		// S/MIME certificates, signing, key management or dual use keys
+		{
+			Types:                []string{"PS"},
+			AllowedExtKeyUsage:   []asn1.ObjectIdentifier{ExtKeyUsageEmailProtection, ExtKeyUsageClientAuth},
+			ExclusiveExtKeyUsage: true,
+		},
+		{
+			Types:             []string{"PS"},
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmRSA},
+			OneOfKeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			AllowedKeyUsage:   x509.KeyUsageContentCommitment | x509.KeyUsageDataEncipherment,
+			ExclusiveKeyUsage: true,
+		},
+		{
+			Types:             []string{"PS"},
+			KeyAlgorithms:     []string{certdata.KeyAlgorithmECDSA},
+			OneOfKeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+			AllowedKeyUsage:   x509.KeyUsageContentCommitment | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+			ExclusiveKeyUsage: true,
+		},
+
+		// Code signing certificates
+		{
+			Types:                []string{"CS", "EVCS"},
+			RequiredKeyUsage:     x509.KeyUsageDigitalSignature,
+			RequiredExtKeyUsage:  []asn1.ObjectIdentifier{ExtKeyUsageCodeSigning},
+			ExclusiveExtKeyUsage: true,
+		},
+
+		// Time stamping certificates (RFC 3161 2.3)
+		{
+			Types:                []string{"TS"},
+			OneOfKeyUsage:        x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment,
+			ExclusiveKeyUsage:    true,
+			ExtKeyUsageCritical:  Required,
+			RequiredExtKeyUsage:  []asn1.ObjectIdentifier{ExtKeyUsageTimeStamping},
+			ExclusiveExtKeyUsage: true,
+		},
+
+		// OCSP signing certificates (RFC 6960 4.2.2.2)
+		{
+			Types:                []string{"OCSP"},
+			RequiredKeyUsage:     x509.KeyUsageDigitalSignature,
+			RequiredExtKeyUsage:  []asn1.ObjectIdentifier{ExtKeyUsageOCSPSigning},
+			ExclusiveExtKeyUsage: true,
+		},
+	}
+}
@@ -0,0 +1,159 @@
+// Package matrix defines which key usages and extended key usages are
+// required, allowed or forbidden for a combination of certificate type and
+// key algorithm.
+//
+// The default matrix follows RFC 5280, RFC 8410, RFC 3161, RFC 6960 and the
+// CA/Browser Forum requirements. A private PKI can replace the matrix with its
+// own rows using Set, preferably before any certificate is checked.
+package matrix
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"sync"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// Requirement defines if something must, may or must not be present
+type Requirement int
+
+// Requirements that can be used in a Row
+const (
+	Allowed Requirement = iota
+	Required
+	Forbidden
+)
+
+// Row contains the key usage requirements for the listed certificate types
+// and key algorithms. An empty list of types or key algorithms matches all of
+// them. All rows that match a certificate are applied.
+type Row struct {
+	Types         []string
+	KeyAlgorithms []string
+
+	// SelfSigned limits the row to self-signed or other certificates, nil
+	// matches both
+	SelfSigned *bool
+
+	// Presence and criticality of the key usage extension
+	KeyUsage         Requirement
+	KeyUsageCritical Requirement
+
+	// RequiredKeyUsage must all be set, at least one of OneOfKeyUsage must be
+	// set and ForbiddenKeyUsage must not be set. When ExclusiveKeyUsage is
+	// true only required, one of and allowed key usages may be set.
+	RequiredKeyUsage  x509.KeyUsage
+	OneOfKeyUsage     x509.KeyUsage
+	AllowedKeyUsage   x509.KeyUsage
+	ForbiddenKeyUsage x509.KeyUsage
+	ExclusiveKeyUsage bool
+
+	// Presence and criticality of the extended key usage extension
+	ExtKeyUsage         Requirement
+	ExtKeyUsageCritical Requirement
+
+	// Extended key usages use the same rules as the key usages
+	RequiredExtKeyUsage  []asn1.ObjectIdentifier
+	AllowedExtKeyUsage   []asn1.ObjectIdentifier
+	ForbiddenExtKeyUsage []asn1.ObjectIdentifier
+	ExclusiveExtKeyUsage bool
+}
+
+// Matches returns true if the row applies to the given certificate type and
+// key algorithm
+func (r Row) Matches(certType, keyAlgorithm string) bool {
+	return contains(r.Types, certType) && contains(r.KeyAlgorithms, keyAlgorithm)
+}
+
+// ForbidsKeyUsage returns true if the row does not allow the key usage
+func (r Row) ForbidsKeyUsage(ku x509.KeyUsage) bool {
+	if r.ForbiddenKeyUsage&ku != 0 {
+		return true
+	}
+	return r.ExclusiveKeyUsage && (r.RequiredKeyUsage|r.OneOfKeyUsage|r.AllowedKeyUsage)&ku == 0
+}
+
+// ForbidsExtKeyUsage returns true if the row does not allow the extended key
+// usage
+func (r Row) ForbidsExtKeyUsage(eku asn1.ObjectIdentifier) bool {
+	if containsOID(r.ForbiddenExtKeyUsage, eku) {
+		return true
+	}
+	return r.ExclusiveExtKeyUsage && !containsOID(r.RequiredExtKeyUsage, eku) && !containsOID(r.AllowedExtKeyUsage, eku)
+}
+
+var (
+	rows []Row
+	m    sync.RWMutex
+)
+
+func init() {
+	rows = Default()
+}
+
+// Set replaces the matrix with the given rows
+func Set(r []Row) {
+	m.Lock()
+	rows = r
+	m.Unlock()
+}
+
+// Rows returns a copy of the current matrix
+func Rows() []Row {
+	m.RLock()
+	defer m.RUnlock()
+	return append([]Row(nil), rows...)
+}
+
+// Match returns all rows that apply to the certificate
+func Match(d *certdata.Data) []Row {
+	keyAlgorithm := d.KeyAlgorithm()
+
+	m.RLock()
+	defer m.RUnlock()
+
+	var match []Row
+	var selfSigned *bool
+	for _, r := range rows {
+		if !r.Matches(d.Type, keyAlgorithm) {
+			continue
+		}
+		if r.SelfSigned != nil {
+			// verifying the signature is expensive, do it once
+			if selfSigned == nil {
+				selfSigned = boolean(d.SelfSigned())
+			}
+			if *r.SelfSigned != *selfSigned {
+				continue
+			}
+		}
+		match = append(match, r)
+	}
+	return match
+}
+
+func boolean(b bool) *bool {
+	return &b
+}
+
+func contains(list []string, s string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOID(list []asn1.ObjectIdentifier, oid asn1.ObjectIdentifier) bool {
+	for _, l := range list {
+		if l.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package matrix
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func testData(t *testing.T, certType string, keyAlgorithm asn1.ObjectIdentifier) *certdata.Data {
+	spki, err := asn1.Marshal(certdata.SubjectPublicKeyInfo{
+		Algorithm:        pkix.AlgorithmIdentifier{Algorithm: keyAlgorithm},
+		SubjectPublicKey: asn1.BitString{Bytes: make([]byte, 32), BitLength: 256},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &certdata.Data{
+		Cert: &x509.Certificate{RawSubjectPublicKeyInfo: spki},
+		Type: certType,
+	}
+}
+
+func TestMatch(t *testing.T) {
+	x25519 := asn1.ObjectIdentifier{1, 3, 101, 110}
+
+	var forbidden x509.KeyUsage
+	for _, r := range Match(testData(t, "PS", x25519)) {
+		if r.ForbidsKeyUsage(x509.KeyUsageDigitalSignature) {
+			forbidden |= x509.KeyUsageDigitalSignature
+		}
+		if r.ForbidsKeyUsage(x509.KeyUsageKeyAgreement) {
+			forbidden |= x509.KeyUsageKeyAgreement
+		}
+	}
+	if forbidden != x509.KeyUsageDigitalSignature {
+		t.Errorf("expected only DigitalSignature to be forbidden for X25519 keys, got %d", forbidden)
+	}
+}
+
+func TestMatchSelfSigned(t *testing.T) {
+	defer Set(Default())
+
+	Set([]Row{
+		{Types: []string{"CA"}, SelfSigned: boolean(true), ExtKeyUsage: Forbidden},
+		{Types: []string{"CA"}, SelfSigned: boolean(false), ExtKeyUsage: Required},
+	})
+
+	rows := Match(testData(t, "CA", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 matching row, got %d", len(rows))
+	}
+	if rows[0].ExtKeyUsage != Required {
+		t.Error("expected the row of certificates that are not self-signed")
+	}
+}
+
+func TestSet(t *testing.T) {
+	defer Set(Default())
+
+	Set([]Row{{
+		Types:                []string{"PRIVATE"},
+		RequiredExtKeyUsage:  []asn1.ObjectIdentifier{{1, 2, 3, 4}},
+		ExclusiveExtKeyUsage: true,
+	}})
+
+	if rows := Match(testData(t, "DV", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1})); len(rows) != 0 {
+		t.Errorf("expected no matching rows for DV certificates, got %d", len(rows))
+	}
+
+	rows := Match(testData(t, "PRIVATE", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 matching row, got %d", len(rows))
+	}
+	if rows[0].ForbidsExtKeyUsage(asn1.ObjectIdentifier{1, 2, 3, 4}) {
+		t.Error("required extended key usage is forbidden")
+	}
+	if !rows[0].ForbidsExtKeyUsage(ExtKeyUsageServerAuth) {
+		t.Error("expected ServerAuth to be forbidden")
+	}
+}
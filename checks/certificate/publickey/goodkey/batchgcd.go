@@ -0,0 +1,55 @@
+package goodkey
+
+import "math/big"
+
+// BatchGCD returns for every modulus the greatest common divisor with the
+// product of all other moduli, using the product and remainder tree algorithm
+// described in "Mining Your Ps and Qs" (Heninger et al.). A result other than
+// 1 means that the modulus shares a prime factor with another modulus in the
+// list. The moduli should not contain duplicates, duplicated moduli result in
+// the modulus itself.
+func BatchGCD(moduli []*big.Int) []*big.Int {
+	gcds := make([]*big.Int, len(moduli))
+	if len(moduli) == 0 {
+		return gcds
+	}
+
+	// product tree, the first level contains the moduli and the last level the
+	// product of all moduli
+	tree := [][]*big.Int{moduli}
+	for level := moduli; len(level) > 1; {
+		next := make([]*big.Int, (len(level)+1)/2)
+		for i := range next {
+			if 2*i+1 < len(level) {
+				next[i] = new(big.Int).Mul(level[2*i], level[2*i+1])
+			} else {
+				next[i] = level[2*i]
+			}
+		}
+		tree = append(tree, next)
+		level = next
+	}
+
+	// remainder tree, every node is reduced modulo the square of its own value
+	remainders := tree[len(tree)-1]
+	for l := len(tree) - 2; l >= 0; l-- {
+		level := tree[l]
+		next := make([]*big.Int, len(level))
+		for i, v := range level {
+			sq := new(big.Int).Mul(v, v)
+			next[i] = new(big.Int).Mod(remainders[i/2], sq)
+		}
+		remainders = next
+	}
+
+	for i, n := range moduli {
+		r := new(big.Int).Quo(remainders[i], n)
+		if r.Sign() == 0 {
+			// n divides the product of all other moduli
+			gcds[i] = new(big.Int).Set(n)
+			continue
+		}
+		gcds[i] = r.GCD(nil, nil, r, n)
+	}
+	return gcds
+}
@@ -8,6 +8,7 @@ package goodkey
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"fmt"
@@ -41,20 +42,71 @@ var (
 // KeyPolicy determines which types of key may be used with various boulder
 // operations.
 type KeyPolicy struct {
-	AllowRSA           bool // Whether RSA keys should be allowed.
-	AllowECDSANISTP256 bool // Whether ECDSA NISTP256 keys should be allowed.
-	AllowECDSANISTP384 bool // Whether ECDSA NISTP384 keys should be allowed.
+	AllowRSA           bool `json:"allowRSA"`           // Whether RSA keys should be allowed.
+	AllowRSAPSS        bool `json:"allowRSAPSS"`        // Whether RSA keys restricted to RSASSA-PSS should be allowed.
+	AllowECDSANISTP256 bool `json:"allowECDSANISTP256"` // Whether ECDSA NISTP256 keys should be allowed.
+	AllowECDSANISTP384 bool `json:"allowECDSANISTP384"` // Whether ECDSA NISTP384 keys should be allowed.
+	AllowECDSANISTP521 bool `json:"allowECDSANISTP521"` // Whether ECDSA NISTP521 keys should be allowed.
+	AllowEd25519       bool `json:"allowEd25519"`       // Whether Ed25519 keys should be allowed.
+	AllowEd448         bool `json:"allowEd448"`         // Whether Ed448 keys should be allowed.
+	AllowX25519        bool `json:"allowX25519"`        // Whether X25519 keys should be allowed.
+	AllowX448          bool `json:"allowX448"`          // Whether X448 keys should be allowed.
+	AllowMLDSA         bool `json:"allowMLDSA"`         // Whether ML-DSA (FIPS 204) keys should be allowed.
+	AllowMLKEM         bool `json:"allowMLKEM"`         // Whether ML-KEM (FIPS 203) keys should be allowed.
+
+	MinRSAKeySize  int   `json:"minRSAKeySize"`  // Minimum RSA modulus size in bits, 0 for the default of 2048.
+	MaxRSAKeySize  int   `json:"maxRSAKeySize"`  // Maximum RSA modulus size in bits, 0 for no maximum.
+	MinRSAExponent int   `json:"minRSAExponent"` // Minimum RSA public exponent, 0 for the default of 2^16+1.
+	RSAExponents   []int `json:"rsaExponents"`   // Allowed RSA public exponents, empty allows all.
 }
 
-// NewKeyPolicy returns a KeyPolicy that allows RSA, ECDSA256 and ECDSA384.
+// Default minimum RSA modulus size and public exponent (BR 6.1.5 and 6.1.6),
+// also used when the KeyPolicy field is 0.
+const (
+	defaultMinRSAKeySize  = 2048
+	defaultMinRSAExponent = (1 << 16) + 1
+)
+
+// NewKeyPolicy returns a KeyPolicy that allows RSA keys of at least 2048 bits,
+// RSA-PSS, ECDSA256 and ECDSA384. Ed25519 and the other key types are not
+// allowed by the BR and have to be enabled explicitly.
 func NewKeyPolicy() KeyPolicy {
 	return KeyPolicy{
 		AllowRSA:           true,
+		AllowRSAPSS:        true,
 		AllowECDSANISTP256: true,
 		AllowECDSANISTP384: true,
+		MinRSAKeySize:      defaultMinRSAKeySize,
+		MinRSAExponent:     defaultMinRSAExponent,
 	}
 }
 
+// Rules of a PolicyError that can't be changed in the policy
+const (
+	ruleKeyTypes       = "keyTypes"
+	ruleKeyEncoding    = "keyEncoding"
+	ruleECDSACurves    = "ecdsaCurves"
+	ruleECDSAPublicKey = "ecdsaPublicKey"
+	ruleRSAModulus     = "rsaModulus"
+	ruleRSAExponent    = "rsaExponent"
+	ruleWeakKeys       = "weakKeys"
+)
+
+// PolicyError is returned when a key violates a rule of the key policy
+type PolicyError struct {
+	Rule string // name of the violated KeyPolicy field or fixed rule
+	msg  string
+}
+
+func (e PolicyError) Error() string {
+	return fmt.Sprintf("%s (key policy %s)", e.msg, e.Rule)
+}
+
+// policyError creates an error for the violated rule of the key policy
+func policyError(rule string, format string, a ...interface{}) error {
+	return PolicyError{Rule: rule, msg: fmt.Sprintf(format, a...)}
+}
+
 // GoodKey returns true if the key is acceptable for both TLS use and account
 // key use (our requirements are the same for either one), according to basic
 // strength and algorithm checking.
@@ -69,9 +121,24 @@ func (policy *KeyPolicy) GoodKey(key crypto.PublicKey) error {
 		return policy.goodKeyECDSA(t)
 	case *ecdsa.PublicKey:
 		return policy.goodKeyECDSA(*t)
+	case ed25519.PublicKey:
+		return policy.goodKeyEd25519(t)
+	case *ed25519.PublicKey:
+		return policy.goodKeyEd25519(*t)
 	default:
-		return fmt.Errorf("Unknown key type %s", reflect.TypeOf(key))
+		return policyError(ruleKeyTypes, "Unknown key type %s", reflect.TypeOf(key))
+	}
+}
+
+// goodKeyEd25519 determines if an Ed25519 pubkey meets our requirements
+func (policy *KeyPolicy) goodKeyEd25519(key ed25519.PublicKey) error {
+	if !policy.AllowEd25519 {
+		return policyError("allowEd25519", "Ed25519 keys are not allowed")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return policyError(ruleKeyEncoding, "Ed25519 public key length must be %d bytes: %d", ed25519.PublicKeySize, len(key))
 	}
+	return nil
 }
 
 // GoodKeyECDSA determines if an ECDSA pubkey meets our requirements
@@ -100,7 +167,7 @@ func (policy *KeyPolicy) goodKeyECDSA(key ecdsa.PublicKey) (err error) {
 	// This code assumes that the point at infinity is (0,0), which is the
 	// case for all supported curves.
 	if isPointAtInfinityNISTP(key.X, key.Y) {
-		return fmt.Errorf("Key x, y must not be the point at infinity")
+		return policyError(ruleECDSAPublicKey, "Key x, y must not be the point at infinity")
 	}
 
 	// SP800-56A § 5.6.2.3.2 Step 2.
@@ -117,11 +184,11 @@ func (policy *KeyPolicy) goodKeyECDSA(key ecdsa.PublicKey) (err error) {
 	// correct representation of an element in the underlying field by verifying
 	// that x and y are integers in [0, p-1].
 	if key.X.Sign() < 0 || key.Y.Sign() < 0 {
-		return fmt.Errorf("Key x, y must not be negative")
+		return policyError(ruleECDSAPublicKey, "Key x, y must not be negative")
 	}
 
 	if key.X.Cmp(params.P) >= 0 || key.Y.Cmp(params.P) >= 0 {
-		return fmt.Errorf("Key x, y must not exceed P-1")
+		return policyError(ruleECDSAPublicKey, "Key x, y must not exceed P-1")
 	}
 
 	// SP800-56A § 5.6.2.3.2 Step 3.
@@ -139,7 +206,7 @@ func (policy *KeyPolicy) goodKeyECDSA(key ecdsa.PublicKey) (err error) {
 	// This proves that the public key is on the correct elliptic curve.
 	// But in practice, this test is provided by crypto/elliptic, so use that.
 	if !key.Curve.IsOnCurve(key.X, key.Y) {
-		return fmt.Errorf("Key point is not on the curve")
+		return policyError(ruleECDSAPublicKey, "Key point is not on the curve")
 	}
 
 	// SP800-56A § 5.6.2.3.2 Step 4.
@@ -155,7 +222,7 @@ func (policy *KeyPolicy) goodKeyECDSA(key ecdsa.PublicKey) (err error) {
 	// n*Q = O iff n*Q is the point at infinity (see step 1).
 	ox, oy := key.Curve.ScalarMult(key.X, key.Y, params.N.Bytes())
 	if !isPointAtInfinityNISTP(ox, oy) {
-		return fmt.Errorf("Public key does not have correct order")
+		return policyError(ruleECDSAPublicKey, "Public key does not have correct order")
 	}
 
 	// End of SP800-56A § 5.6.2.3.2 Public Key Validation Routine.
@@ -175,34 +242,55 @@ func isPointAtInfinityNISTP(x, y *big.Int) bool {
 func (policy *KeyPolicy) goodCurve(c elliptic.Curve) (err error) {
 	// Simply use a whitelist for now.
 	params := c.Params()
-	switch {
-	case policy.AllowECDSANISTP256 && params == elliptic.P256().Params():
-		return nil
-	case policy.AllowECDSANISTP384 && params == elliptic.P384().Params():
-		return nil
+	var allowed bool
+	var rule string
+	switch params {
+	case elliptic.P256().Params():
+		allowed, rule = policy.AllowECDSANISTP256, "allowECDSANISTP256"
+	case elliptic.P384().Params():
+		allowed, rule = policy.AllowECDSANISTP384, "allowECDSANISTP384"
+	case elliptic.P521().Params():
+		allowed, rule = policy.AllowECDSANISTP521, "allowECDSANISTP521"
 	default:
-		return fmt.Errorf(fmt.Sprintf("ECDSA curve %v not allowed", params.Name))
+		rule = ruleECDSACurves
+	}
+	if !allowed {
+		return policyError(rule, "ECDSA curve %v not allowed", params.Name)
 	}
+	return nil
 }
 
 // GoodKeyRSA determines if a RSA pubkey meets our requirements
 func (policy *KeyPolicy) goodKeyRSA(key rsa.PublicKey) (err error) {
 	if !policy.AllowRSA {
-		return fmt.Errorf("RSA keys are not allowed")
+		return policyError("allowRSA", "RSA keys are not allowed")
 	}
+	return policy.goodRSAModulus(key)
+}
 
+// goodRSAModulus determines if the modulus and exponent of a RSA or RSA-PSS
+// pubkey meet our requirements
+func (policy *KeyPolicy) goodRSAModulus(key rsa.PublicKey) (err error) {
 	// Baseline Requirements Appendix A
 	// Modulus must be >= 2048 bits
-	// Removed max keySize from original package version
+	// Removed max keySize from original package version, the maximum is
+	// optional in the key policy.
 	modulus := key.N
 	modulusBitLen := modulus.BitLen()
-	if modulusBitLen < 2048 {
-		return fmt.Errorf(fmt.Sprintf("Key too small: %d", modulusBitLen))
+	minKeySize := policy.MinRSAKeySize
+	if minKeySize == 0 {
+		minKeySize = defaultMinRSAKeySize
+	}
+	if modulusBitLen < minKeySize {
+		return policyError("minRSAKeySize", "Key too small: %d, minimum %d", modulusBitLen, minKeySize)
+	}
+	if policy.MaxRSAKeySize > 0 && modulusBitLen > policy.MaxRSAKeySize {
+		return policyError("maxRSAKeySize", "Key too large: %d, maximum %d", modulusBitLen, policy.MaxRSAKeySize)
 	}
 	// Bit lengths that are not a multiple of 8 may cause problems on some
 	// client implementations.
 	if modulusBitLen%8 != 0 {
-		return fmt.Errorf(fmt.Sprintf("Key length wasn't a multiple of 8: %d", modulusBitLen))
+		return policyError(ruleRSAModulus, "Key length wasn't a multiple of 8: %d", modulusBitLen)
 	}
 	// The CA SHALL confirm that the value of the public exponent is an
 	// odd number equal to 3 or more. Additionally, the public exponent
@@ -210,19 +298,49 @@ func (policy *KeyPolicy) goodKeyRSA(key rsa.PublicKey) (err error) {
 	// NOTE: rsa.PublicKey cannot represent an exponent part greater than
 	// 2^32 - 1 or 2^64 - 1, because it stores E as an integer. So we
 	// don't need to check the upper bound.
-	if (key.E%2) == 0 || key.E < ((1<<16)+1) {
-		return fmt.Errorf(fmt.Sprintf("Key exponent should be odd and >2^16: %d", key.E))
+	if (key.E%2) == 0 || key.E < 3 {
+		return policyError(ruleRSAExponent, "Key exponent should be odd and >=3: %d", key.E)
+	}
+	minExponent := policy.MinRSAExponent
+	if minExponent == 0 {
+		minExponent = defaultMinRSAExponent
+	}
+	if key.E < minExponent {
+		return policyError("minRSAExponent", "Key exponent should be at least %d: %d", minExponent, key.E)
+	}
+	if len(policy.RSAExponents) > 0 && !containsInt(policy.RSAExponents, key.E) {
+		return policyError("rsaExponents", "Key exponent not allowed: %d, allowed %v", key.E, policy.RSAExponents)
 	}
 	// The modulus SHOULD also have the following characteristics: an odd
 	// number, not the power of a prime, and have no factors smaller than 752.
 	// TODO: We don't yet check for "power of a prime."
 	if checkSmallPrimes(modulus) {
-		return fmt.Errorf("Key divisible by small prime")
+		return policyError(ruleRSAModulus, "Key divisible by small prime")
+	}
+
+	// Known weak key classes
+	if isDebianWeakKey(key) {
+		return policyError(ruleWeakKeys, "Key is a Debian weak key (CVE-2008-0166)")
+	}
+	if isROCAWeakKey(key) {
+		return policyError(ruleWeakKeys, "Key is vulnerable to ROCA (CVE-2017-15361)")
+	}
+	if isFermatWeakKey(key) {
+		return policyError(ruleWeakKeys, "Key can be factored using Fermat's method")
 	}
 
 	return nil
 }
 
+func containsInt(list []int, i int) bool {
+	for _, l := range list {
+		if l == i {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns true iff integer i is divisible by any of the primes in smallPrimes.
 //
 // Short circuits; execution time is dependent on i. Do not use this on secret
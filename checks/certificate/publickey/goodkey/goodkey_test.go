@@ -0,0 +1,169 @@
+package goodkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+)
+
+func TestKeyPolicyRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := NewKeyPolicy()
+	if err := policy.GoodKey(&priv.PublicKey); err != nil {
+		t.Errorf("unexpected error for the default key policy: %s", err)
+	}
+
+	testCases := []struct {
+		Name   string
+		Update func(*KeyPolicy)
+		Rule   string
+	}{
+		{"RSA not allowed", func(p *KeyPolicy) { p.AllowRSA = false }, "allowRSA"},
+		{"Minimum key size", func(p *KeyPolicy) { p.MinRSAKeySize = 3072 }, "minRSAKeySize"},
+		{"Maximum key size", func(p *KeyPolicy) { p.MaxRSAKeySize = 1024 }, "maxRSAKeySize"},
+		{"Allowed exponents", func(p *KeyPolicy) { p.RSAExponents = []int{3} }, "rsaExponents"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			policy := NewKeyPolicy()
+			tc.Update(&policy)
+
+			err := policy.GoodKey(&priv.PublicKey)
+			perr, ok := err.(PolicyError)
+			if !ok {
+				t.Fatalf("expected a PolicyError, got %v", err)
+			}
+			if perr.Rule != tc.Rule {
+				t.Errorf("expected violated rule %q, got %q", tc.Rule, perr.Rule)
+			}
+		})
+	}
+}
+
+func TestKeyPolicyDefaults(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Exponent int
+		Rule     string
+	}{
+		{"Minimum key size", 65537, "minRSAKeySize"},
+		{"Minimum exponent", 3, "minRSAExponent"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// zero minimums use the defaults of NewKeyPolicy
+			policy := KeyPolicy{AllowRSA: true}
+			if tc.Rule == "minRSAExponent" {
+				policy.MinRSAKeySize = 1024
+			}
+
+			err := policy.GoodKey(&rsa.PublicKey{N: priv.N, E: tc.Exponent})
+			perr, ok := err.(PolicyError)
+			if !ok {
+				t.Fatalf("expected a PolicyError, got %v", err)
+			}
+			if perr.Rule != tc.Rule {
+				t.Errorf("expected violated rule %q, got %q", tc.Rule, perr.Rule)
+			}
+		})
+	}
+}
+
+func TestKeyPolicyECDSA(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Curve  elliptic.Curve
+		Update func(*KeyPolicy)
+		Rule   string
+	}{
+		{"P-256 not allowed", elliptic.P256(), func(p *KeyPolicy) { p.AllowECDSANISTP256 = false }, "allowECDSANISTP256"},
+		{"P-521 not allowed", elliptic.P521(), func(p *KeyPolicy) {}, "allowECDSANISTP521"},
+		{"Curve without a policy switch", elliptic.P224(), func(p *KeyPolicy) {}, "ecdsaCurves"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(tc.Curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			policy := NewKeyPolicy()
+			tc.Update(&policy)
+
+			for _, err := range []error{policy.GoodKey(&priv.PublicKey), policy.GoodSubjectPublicKeyInfo(der)} {
+				perr, ok := err.(PolicyError)
+				if !ok {
+					t.Fatalf("expected a PolicyError, got %v", err)
+				}
+				if perr.Rule != tc.Rule {
+					t.Errorf("expected violated rule %q, got %q", tc.Rule, perr.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyPolicyEd25519(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := NewKeyPolicy()
+	policy.AllowEd25519 = true
+	for _, err := range []error{policy.GoodKey(pub), policy.GoodSubjectPublicKeyInfo(der)} {
+		if err != nil {
+			t.Errorf("unexpected error for an allowed Ed25519 key: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		Name  string
+		Key   ed25519.PublicKey
+		Allow bool
+		Rule  string
+	}{
+		{"Ed25519 not allowed", pub, false, "allowEd25519"},
+		{"Invalid key length", pub[:16], true, "keyEncoding"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			policy := NewKeyPolicy()
+			policy.AllowEd25519 = tc.Allow
+
+			err := policy.GoodKey(tc.Key)
+			perr, ok := err.(PolicyError)
+			if !ok {
+				t.Fatalf("expected a PolicyError, got %v", err)
+			}
+			if perr.Rule != tc.Rule {
+				t.Errorf("expected violated rule %q, got %q", tc.Rule, perr.Rule)
+			}
+		})
+	}
+}
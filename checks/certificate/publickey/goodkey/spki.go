@@ -0,0 +1,256 @@
+package goodkey
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+
+	oidSHA1   = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidMGF1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 8}
+)
+
+// Encoded public key sizes in bytes as defined in RFC 8410, FIPS 203 and
+// FIPS 204.
+var publicKeySize = map[string]int{
+	certdata.KeyAlgorithmEd25519:   32,
+	certdata.KeyAlgorithmEd448:     57,
+	certdata.KeyAlgorithmX25519:    32,
+	certdata.KeyAlgorithmX448:      56,
+	certdata.KeyAlgorithmMLDSA44:   1312,
+	certdata.KeyAlgorithmMLDSA65:   1952,
+	certdata.KeyAlgorithmMLDSA87:   2592,
+	certdata.KeyAlgorithmMLKEM512:  800,
+	certdata.KeyAlgorithmMLKEM768:  1184,
+	certdata.KeyAlgorithmMLKEM1024: 1568,
+}
+
+// rsaPublicKey as defined in RFC 3447 A.1.1, the exponent is parsed as big
+// integer to detect exponents that do not fit in rsa.PublicKey.
+type rsaPublicKey struct {
+	N *big.Int
+	E *big.Int
+}
+
+// rsaPSSParameters as defined in RFC 4055 3.1
+type rsaPSSParameters struct {
+	Hash         algorithmIdentifier `asn1:"optional,explicit,tag:0"`
+	MGF          algorithmIdentifier `asn1:"optional,explicit,tag:1"`
+	SaltLength   int                 `asn1:"optional,explicit,tag:2,default:20"`
+	TrailerField int                 `asn1:"optional,explicit,tag:3,default:1"`
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// GoodSubjectPublicKeyInfo verifies the encoding of the DER encoded
+// SubjectPublicKeyInfo and returns an error if the key does not meet our
+// requirements. Unlike GoodKey it supports key algorithms that can't be
+// parsed by crypto/x509.
+func (policy *KeyPolicy) GoodSubjectPublicKeyInfo(der []byte) error {
+	spki, err := certdata.ParseSubjectPublicKeyInfo(der)
+	if err != nil {
+		return policyError(ruleKeyEncoding, "Invalid SubjectPublicKeyInfo: %s", err.Error())
+	}
+
+	params := spki.Algorithm.Parameters
+	algorithm := certdata.KeyAlgorithm(spki.Algorithm.Algorithm)
+	switch algorithm {
+	case certdata.KeyAlgorithmRSA:
+		// RFC 3279 2.3.1: the parameters field MUST have ASN.1 type NULL
+		if !isNull(params) {
+			return policyError(ruleKeyEncoding, "RSA key parameters must be NULL")
+		}
+		key, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return policyError(ruleKeyEncoding, "Invalid RSA public key: %s", err.Error())
+		}
+		return policy.GoodKey(key)
+
+	case certdata.KeyAlgorithmRSAPSS:
+		if !policy.AllowRSAPSS {
+			return policyError("allowRSAPSS", "RSA-PSS keys are not allowed")
+		}
+		if err := goodRSAPSSParameters(params); err != nil {
+			return err
+		}
+		key, err := parseRSAPublicKey(spki.SubjectPublicKey)
+		if err != nil {
+			return err
+		}
+		return policy.goodRSAModulus(*key)
+
+	case certdata.KeyAlgorithmECDSA:
+		var curve asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(params.FullBytes, &curve); err != nil {
+			// RFC 5480 2.1.1: implicitCurve and specifiedCurve MUST NOT be used
+			return policyError(ruleKeyEncoding, "ECDSA key parameters must be a namedCurve")
+		}
+		switch {
+		case curve.Equal(oidNamedCurveP256), curve.Equal(oidNamedCurveP384), curve.Equal(oidNamedCurveP521):
+		default:
+			return policyError(ruleECDSACurves, "ECDSA curve %s not allowed", curve.String())
+		}
+		// RFC 5480 2.2: the uncompressed form MUST be supported, the compressed
+		// form MAY be supported but is not by most clients.
+		if len(spki.SubjectPublicKey.Bytes) == 0 || spki.SubjectPublicKey.Bytes[0] != 4 {
+			return policyError(ruleKeyEncoding, "ECDSA public key is not in uncompressed form")
+		}
+		key, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return policyError(ruleKeyEncoding, "Invalid ECDSA public key: %s", err.Error())
+		}
+		return policy.GoodKey(key)
+
+	case certdata.KeyAlgorithmEd25519:
+		if !policy.AllowEd25519 {
+			return policyError("allowEd25519", "Ed25519 keys are not allowed")
+		}
+	case certdata.KeyAlgorithmEd448:
+		if !policy.AllowEd448 {
+			return policyError("allowEd448", "Ed448 keys are not allowed")
+		}
+	case certdata.KeyAlgorithmX25519:
+		if !policy.AllowX25519 {
+			return policyError("allowX25519", "X25519 keys are not allowed")
+		}
+	case certdata.KeyAlgorithmX448:
+		if !policy.AllowX448 {
+			return policyError("allowX448", "X448 keys are not allowed")
+		}
+	case certdata.KeyAlgorithmMLDSA44, certdata.KeyAlgorithmMLDSA65, certdata.KeyAlgorithmMLDSA87:
+		if !policy.AllowMLDSA {
+			return policyError("allowMLDSA", "%s keys are not allowed", algorithm)
+		}
+	case certdata.KeyAlgorithmMLKEM512, certdata.KeyAlgorithmMLKEM768, certdata.KeyAlgorithmMLKEM1024:
+		if !policy.AllowMLKEM {
+			return policyError("allowMLKEM", "%s keys are not allowed", algorithm)
+		}
+	default:
+		return policyError(ruleKeyTypes, "Unknown key algorithm %s", spki.Algorithm.Algorithm.String())
+	}
+
+	// RFC 8410 3, FIPS 203 and FIPS 204 SubjectPublicKeyInfo encodings: the
+	// parameters MUST be absent and the key is a fixed length octet string.
+	if len(params.FullBytes) > 0 {
+		return policyError(ruleKeyEncoding, "%s key parameters must be absent", algorithm)
+	}
+	if spki.SubjectPublicKey.BitLength%8 != 0 {
+		return policyError(ruleKeyEncoding, "%s public key contains unused bits", algorithm)
+	}
+	if size := publicKeySize[algorithm]; len(spki.SubjectPublicKey.Bytes) != size {
+		return policyError(ruleKeyEncoding, "%s public key length must be %d bytes: %d", algorithm, size, len(spki.SubjectPublicKey.Bytes))
+	}
+
+	return nil
+}
+
+// goodRSAPSSParameters verifies that the RSASSA-PSS parameters are consistent,
+// absent parameters do not restrict the use of the key.
+//
+// RFC 4055 3.1 and 3.3:
+//
+//  The hashAlgorithm and maskGenAlgorithm hash SHOULD be the same. The
+//  saltLength SHOULD be the length of the hash output and the trailerField
+//  MUST be 1.
+//
+func goodRSAPSSParameters(params asn1.RawValue) error {
+	if len(params.FullBytes) == 0 {
+		return nil
+	}
+
+	var p rsaPSSParameters
+	if rest, err := asn1.Unmarshal(params.FullBytes, &p); err != nil || len(rest) > 0 {
+		return policyError(ruleKeyEncoding, "RSA-PSS key parameters can't be decoded")
+	}
+
+	// default sha1
+	hash := oidSHA1
+	if len(p.Hash.Algorithm) > 0 {
+		if err := goodHashParameters(p.Hash.Parameters); err != nil {
+			return err
+		}
+		hash = p.Hash.Algorithm
+	}
+
+	// default mgf1SHA1
+	mgfHash := oidSHA1
+	if len(p.MGF.Algorithm) > 0 {
+		if !p.MGF.Algorithm.Equal(oidMGF1) {
+			return policyError(ruleKeyEncoding, "RSA-PSS key maskGenAlgorithm %s is not MGF1", p.MGF.Algorithm.String())
+		}
+		var ai algorithmIdentifier
+		if _, err := asn1.Unmarshal(p.MGF.Parameters.FullBytes, &ai); err != nil {
+			return policyError(ruleKeyEncoding, "RSA-PSS key MGF1 hash algorithm can't be decoded")
+		}
+		if err := goodHashParameters(ai.Parameters); err != nil {
+			return err
+		}
+		mgfHash = ai.Algorithm
+	}
+
+	var size int
+	switch {
+	case hash.Equal(oidSHA256):
+		size = 32
+	case hash.Equal(oidSHA384):
+		size = 48
+	case hash.Equal(oidSHA512):
+		size = 64
+	default:
+		return policyError(ruleKeyEncoding, "RSA-PSS key hash algorithm %s not allowed", hash.String())
+	}
+
+	if !mgfHash.Equal(hash) {
+		return policyError(ruleKeyEncoding, "RSA-PSS key MGF1 hash algorithm does not match the hash algorithm")
+	}
+	if p.SaltLength != size {
+		return policyError(ruleKeyEncoding, "RSA-PSS key salt length should be %d: %d", size, p.SaltLength)
+	}
+	if p.TrailerField != 1 {
+		return policyError(ruleKeyEncoding, "RSA-PSS key trailer field must be 1: %d", p.TrailerField)
+	}
+
+	return nil
+}
+
+// goodHashParameters verifies the parameters of a hash AlgorithmIdentifier,
+// RFC 4055 2.1: the parameters are absent or NULL
+func goodHashParameters(params asn1.RawValue) error {
+	if len(params.FullBytes) > 0 && !isNull(params) {
+		return policyError(ruleKeyEncoding, "RSA-PSS key hash algorithm parameters must be absent or NULL")
+	}
+	return nil
+}
+
+// parseRSAPublicKey decodes the RSAPublicKey of the subjectPublicKey
+func parseRSAPublicKey(bs asn1.BitString) (*rsa.PublicKey, error) {
+	var pub rsaPublicKey
+	if rest, err := asn1.Unmarshal(bs.RightAlign(), &pub); err != nil || len(rest) > 0 {
+		return nil, policyError(ruleKeyEncoding, "RSA public key can't be decoded")
+	}
+	if pub.N == nil || pub.N.Sign() <= 0 {
+		return nil, policyError(ruleKeyEncoding, "RSA modulus is not a positive number")
+	}
+	if pub.E == nil || pub.E.Sign() <= 0 || pub.E.BitLen() > 31 {
+		return nil, policyError(ruleKeyEncoding, "RSA public exponent is not a positive 32 bit number")
+	}
+	return &rsa.PublicKey{N: pub.N, E: int(pub.E.Int64())}, nil
+}
+
+func isNull(v asn1.RawValue) bool {
+	return v.Class == asn1.ClassUniversal && v.Tag == asn1.TagNull && len(v.Bytes) == 0
+}
@@ -0,0 +1,126 @@
+package goodkey
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func marshalSPKI(t *testing.T, algorithm asn1.ObjectIdentifier, params []byte, key []byte) []byte {
+	ai := pkix.AlgorithmIdentifier{Algorithm: algorithm}
+	if params != nil {
+		ai.Parameters = asn1.RawValue{FullBytes: params}
+	}
+	der, err := asn1.Marshal(certdata.SubjectPublicKeyInfo{
+		Algorithm:        ai,
+		SubjectPublicKey: asn1.BitString{Bytes: key, BitLength: len(key) * 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func marshalPSSParams(t *testing.T, hash, mgfHash asn1.ObjectIdentifier, saltLength int) []byte {
+	mgfHashAlg, err := asn1.Marshal(pkix.AlgorithmIdentifier{Algorithm: mgfHash, Parameters: asn1.NullRawValue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := asn1.Marshal(rsaPSSParameters{
+		Hash:         algorithmIdentifier{Algorithm: hash, Parameters: asn1.NullRawValue},
+		MGF:          algorithmIdentifier{Algorithm: oidMGF1, Parameters: asn1.RawValue{FullBytes: mgfHashAlg}},
+		SaltLength:   saltLength,
+		TrailerField: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return params
+}
+
+func TestGoodSubjectPublicKeyInfo(t *testing.T) {
+	oidEd25519 := asn1.ObjectIdentifier{1, 3, 101, 112}
+	oidMLDSA44 := asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 17}
+	oidMLKEM768 := asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}
+	oidRSAPSS := asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := asn1.Marshal(rsaPublicKey{N: priv.N, E: big.NewInt(int64(priv.E))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := NewKeyPolicy()
+	policy.AllowEd25519 = true
+	policy.AllowMLDSA = true
+	policy.AllowMLKEM = true
+
+	testCases := []struct {
+		Name          string
+		SPKI          []byte
+		ExpectedError string
+	}{
+		{
+			Name: "Valid: Ed25519",
+			SPKI: marshalSPKI(t, oidEd25519, nil, make([]byte, 32)),
+		},
+		{
+			Name:          "Invalid: Ed25519 with parameters",
+			SPKI:          marshalSPKI(t, oidEd25519, []byte{5, 0}, make([]byte, 32)),
+			ExpectedError: "Ed25519 key parameters must be absent (key policy keyEncoding)",
+		},
+		{
+			Name:          "Invalid: Ed25519 key length",
+			SPKI:          marshalSPKI(t, oidEd25519, nil, make([]byte, 33)),
+			ExpectedError: "Ed25519 public key length must be 32 bytes: 33 (key policy keyEncoding)",
+		},
+		{
+			Name: "Valid: ML-DSA-44",
+			SPKI: marshalSPKI(t, oidMLDSA44, nil, make([]byte, 1312)),
+		},
+		{
+			Name:          "Invalid: ML-KEM-768 key length",
+			SPKI:          marshalSPKI(t, oidMLKEM768, nil, make([]byte, 800)),
+			ExpectedError: "ML-KEM-768 public key length must be 1184 bytes: 800 (key policy keyEncoding)",
+		},
+		{
+			Name: "Valid: RSA-PSS SHA-256",
+			SPKI: marshalSPKI(t, oidRSAPSS, marshalPSSParams(t, oidSHA256, oidSHA256, 32), rsaKey),
+		},
+		{
+			Name:          "Invalid: RSA-PSS MGF1 hash mismatch",
+			SPKI:          marshalSPKI(t, oidRSAPSS, marshalPSSParams(t, oidSHA256, oidSHA384, 32), rsaKey),
+			ExpectedError: "RSA-PSS key MGF1 hash algorithm does not match the hash algorithm (key policy keyEncoding)",
+		},
+		{
+			Name:          "Invalid: RSA-PSS salt length",
+			SPKI:          marshalSPKI(t, oidRSAPSS, marshalPSSParams(t, oidSHA384, oidSHA384, 32), rsaKey),
+			ExpectedError: "RSA-PSS key salt length should be 48: 32 (key policy keyEncoding)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := policy.GoodSubjectPublicKeyInfo(tc.SPKI)
+			switch {
+			case err == nil && len(tc.ExpectedError) > 0:
+				t.Errorf("expected error %q, got none", tc.ExpectedError)
+			case err != nil && err.Error() != tc.ExpectedError:
+				t.Errorf("expected error %q, got %q", tc.ExpectedError, err.Error())
+			}
+		})
+	}
+
+	defaultPolicy := NewKeyPolicy()
+	if err := defaultPolicy.GoodSubjectPublicKeyInfo(marshalSPKI(t, oidEd25519, nil, make([]byte, 32))); err == nil {
+		t.Error("expected Ed25519 keys not to be allowed by the default policy")
+	}
+}
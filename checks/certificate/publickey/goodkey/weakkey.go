@@ -0,0 +1,163 @@
+package goodkey
+
+import (
+	"bufio"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+)
+
+// fermatRounds is the number of iterations used to find close primes
+const fermatRounds = 100
+
+var (
+	debianBlocklist map[string]bool
+	blocklistMutex  sync.RWMutex
+)
+
+// LoadDebianBlocklist loads a list of RSA moduli generated by the Debian
+// OpenSSL PRNG (CVE-2008-0166) from the given file. The file uses the format
+// of the openssl-blacklist package, every line contains the last 20 hex
+// characters of the SHA-1 hash of "Modulus=<HEX>\n", lines starting with a #
+// are ignored.
+func LoadDebianBlocklist(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ReadDebianBlocklist(f)
+}
+
+// ReadDebianBlocklist adds the entries of a Debian weak key blocklist
+func ReadDebianBlocklist(r io.Reader) error {
+	list := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(line) != 20 {
+			return fmt.Errorf("Invalid Debian blocklist entry: %s", line)
+		}
+		if _, err := hex.DecodeString(line); err != nil {
+			return fmt.Errorf("Invalid Debian blocklist entry: %s", line)
+		}
+		list[strings.ToLower(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	blocklistMutex.Lock()
+	if debianBlocklist == nil {
+		debianBlocklist = list
+	} else {
+		for k := range list {
+			debianBlocklist[k] = true
+		}
+	}
+	blocklistMutex.Unlock()
+	return nil
+}
+
+// isDebianWeakKey returns true if the modulus is listed in the loaded Debian
+// weak key blocklist
+func isDebianWeakKey(key rsa.PublicKey) bool {
+	blocklistMutex.RLock()
+	defer blocklistMutex.RUnlock()
+	if len(debianBlocklist) == 0 {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(fmt.Sprintf("Modulus=%X\n", key.N)))
+	return debianBlocklist[hex.EncodeToString(sum[:])[20:]]
+}
+
+// Primes and generator used to detect ROCA (CVE-2017-15361) vulnerable keys
+// as published in https://github.com/titanous/rocacheck
+var rocaPrimes = []int64{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71,
+	73, 79, 83, 89, 97, 101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151,
+	157, 163, 167,
+}
+
+const rocaGenerator = 65537
+
+var (
+	rocaSingleton sync.Once
+	rocaMarkers   []rocaMarker
+)
+
+// rocaMarker contains the elements of the subgroup generated by 65537 modulo
+// the prime
+type rocaMarker struct {
+	prime    *big.Int
+	subgroup map[int64]bool
+}
+
+// isROCAWeakKey returns true if the modulus has the structure of keys that are
+// generated by the vulnerable Infineon RSALib. The modulus of these keys is an
+// element of the subgroup generated by 65537 modulo each of the primes.
+func isROCAWeakKey(key rsa.PublicKey) bool {
+	rocaSingleton.Do(func() {
+		for _, p := range rocaPrimes {
+			m := rocaMarker{prime: big.NewInt(p), subgroup: make(map[int64]bool)}
+			for e := int64(1); !m.subgroup[e]; e = (e * rocaGenerator) % p {
+				m.subgroup[e] = true
+			}
+			rocaMarkers = append(rocaMarkers, m)
+		}
+	})
+
+	var r big.Int
+	for _, m := range rocaMarkers {
+		if !m.subgroup[r.Mod(key.N, m.prime).Int64()] {
+			return false
+		}
+	}
+	return true
+}
+
+// isFermatWeakKey returns true if the modulus can be factored using Fermat's
+// factorization method, which is the case if both primes are close to the
+// square root of the modulus.
+func isFermatWeakKey(key rsa.PublicKey) bool {
+	n := key.N
+	if n.Sign() <= 0 {
+		return false
+	}
+
+	// a = ceil(sqrt(n))
+	a := new(big.Int).Sqrt(n)
+	if new(big.Int).Mul(a, a).Cmp(n) != 0 {
+		a.Add(a, big.NewInt(1))
+	}
+
+	b2 := new(big.Int)
+	b := new(big.Int)
+	one := big.NewInt(1)
+	for i := 0; i < fermatRounds; i++ {
+		// b^2 = a^2 - n, the modulus is factored when b^2 is a square
+		b2.Mul(a, a)
+		b2.Sub(b2, n)
+		b.Sqrt(b2)
+		if b.Mul(b, b).Cmp(b2) == 0 {
+			// n = (a - b) * (a + b), a - b = 1 is not a factorization
+			b.Sqrt(b2)
+			if new(big.Int).Sub(a, b).Cmp(one) > 0 {
+				return true
+			}
+		}
+		a.Add(a, one)
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package goodkey
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDebianBlocklist(t *testing.T) {
+	defer func() { debianBlocklist = nil }()
+
+	key := rsa.PublicKey{N: big.NewInt(0xc0ffee), E: 65537}
+	sum := sha1.Sum([]byte(fmt.Sprintf("Modulus=%X\n", key.N)))
+
+	list := "# test blocklist\n" + hex.EncodeToString(sum[:])[20:] + "\n"
+	if err := ReadDebianBlocklist(strings.NewReader(list)); err != nil {
+		t.Fatal(err)
+	}
+	if !isDebianWeakKey(key) {
+		t.Error("expected blocklisted key to be detected")
+	}
+	if isDebianWeakKey(rsa.PublicKey{N: big.NewInt(0xdecaf), E: 65537}) {
+		t.Error("unexpected blocklist match")
+	}
+}
+
+func TestROCAWeakKey(t *testing.T) {
+	// every power of the generator is an element of all subgroups
+	n := new(big.Int).Exp(big.NewInt(rocaGenerator), big.NewInt(200), nil)
+	if !isROCAWeakKey(rsa.PublicKey{N: n, E: 65537}) {
+		t.Error("expected ROCA structure to be detected")
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isROCAWeakKey(priv.PublicKey) {
+		t.Error("unexpected ROCA detection on a random key")
+	}
+}
+
+func TestFermatWeakKey(t *testing.T) {
+	p, err := rand.Prime(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// next prime after p
+	q := new(big.Int).Add(p, big.NewInt(2))
+	for !q.ProbablyPrime(20) {
+		q.Add(q, big.NewInt(2))
+	}
+
+	if !isFermatWeakKey(rsa.PublicKey{N: new(big.Int).Mul(p, q), E: 65537}) {
+		t.Error("expected close primes to be detected")
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isFermatWeakKey(priv.PublicKey) {
+		t.Error("unexpected Fermat factorization of a random key")
+	}
+}
+
+func TestBatchGCD(t *testing.T) {
+	primes := []int64{1009, 1013, 1019, 1021, 1031, 1033}
+	moduli := []*big.Int{
+		big.NewInt(primes[0] * primes[1]),
+		big.NewInt(primes[2] * primes[3]),
+		big.NewInt(primes[0] * primes[4]),
+		big.NewInt(primes[5] * primes[1]),
+		big.NewInt(primes[5] * primes[3]),
+	}
+	expected := []int64{primes[0] * primes[1], primes[3], primes[0], primes[5] * primes[1], primes[5] * primes[3]}
+
+	for i, gcd := range BatchGCD(moduli) {
+		if gcd.Int64() != expected[i] {
+			t.Errorf("expected gcd %d for modulus %d, got %s", expected[i], i, gcd)
+		}
+	}
+}
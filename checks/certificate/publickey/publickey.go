@@ -1,7 +1,11 @@
 package publickey
 
 import (
+	"bytes"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -11,20 +15,84 @@ import (
 
 const checkName = "Public Key Check"
 
+var (
+	keyPolicy     = goodkey.NewKeyPolicy()
+	typeKeyPolicy = make(map[string]goodkey.KeyPolicy)
+	profilePolicy = make(map[string]goodkey.KeyPolicy)
+	policyMutex   sync.RWMutex
+)
+
 func init() {
 	checks.RegisterCertificateCheck(checkName, nil, Check)
 }
 
+// SetKeyPolicy sets the key policy that is used for all certificate types
+// without a specific key policy
+func SetKeyPolicy(p goodkey.KeyPolicy) {
+	policyMutex.Lock()
+	keyPolicy = p
+	policyMutex.Unlock()
+}
+
+// SetTypeKeyPolicy sets the key policy for a certificate type (DV, OV, EV,
+// PS, CS, ...), overriding the default key policy
+func SetTypeKeyPolicy(certType string, p goodkey.KeyPolicy) {
+	policyMutex.Lock()
+	typeKeyPolicy[certType] = p
+	policyMutex.Unlock()
+}
+
+// SetProfileKeyPolicy sets the key policy for the certificates of a profile,
+// overriding the key policy of the certificate type
+func SetProfileKeyPolicy(profile string, p goodkey.KeyPolicy) {
+	policyMutex.Lock()
+	profilePolicy[profile] = p
+	policyMutex.Unlock()
+}
+
+// KeyPolicy returns the key policy that is used for the certificate type
+func KeyPolicy(certType string) goodkey.KeyPolicy {
+	policyMutex.RLock()
+	defer policyMutex.RUnlock()
+	if p, ok := typeKeyPolicy[certType]; ok {
+		return p
+	}
+	return keyPolicy
+}
+
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	gkp := goodkey.NewKeyPolicy()
-	err := gkp.GoodKey(d.Cert.PublicKey)
+	gkp := KeyPolicy(d.Type)
+	if d.Profile != nil {
+		policyMutex.RLock()
+		if p, ok := profilePolicy[d.Profile.Name]; ok {
+			gkp = p
+		}
+		policyMutex.RUnlock()
+	}
+	err := gkp.GoodSubjectPublicKeyInfo(d.Cert.RawSubjectPublicKeyInfo)
 	if err != nil {
-		e.Err("Certificate %s", strings.ToLower(err.Error()))
-		return e
+		e.Err("Certificate %s", lowerFirst(err.Error()))
+	}
+
+	// The key of the issuer is evaluated with the key policy of a CA
+	if d.Issuer != nil && !bytes.Equal(d.Issuer.Raw, d.Cert.Raw) {
+		gkp = KeyPolicy("CA")
+		if err = gkp.GoodSubjectPublicKeyInfo(d.Issuer.RawSubjectPublicKeyInfo); err != nil {
+			e.Err("Issuer %s", lowerFirst(err.Error()))
+		}
 	}
 
 	return e
 }
+
+// lowerFirst lower cases the first word of a message unless it is an acronym
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	if next, _ := utf8.DecodeRuneInString(s[n:]); unicode.IsUpper(next) {
+		return s
+	}
+	return strings.ToLower(string(r)) + s[n:]
+}
@@ -0,0 +1,89 @@
+package publickey
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// newCertificate returns a certificate that contains only the encoding of the
+// public key on the given curve
+func newCertificate(t *testing.T, c elliptic.Curve) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &x509.Certificate{Raw: spki, RawSubjectPublicKeyInfo: spki}
+}
+
+func TestCheck(t *testing.T) {
+	p256 := newCertificate(t, elliptic.P256())
+	p521 := newCertificate(t, elliptic.P521())
+
+	testCases := []struct {
+		Name           string
+		Cert           *x509.Certificate
+		Issuer         *x509.Certificate
+		ExpectedErrors []string
+	}{
+		{
+			Name:   "Valid: allowed certificate and issuer key",
+			Cert:   p256,
+			Issuer: newCertificate(t, elliptic.P384()),
+		},
+		{
+			Name: "Valid: no issuer",
+			Cert: p256,
+		},
+		{
+			Name:   "Invalid: issuer key not allowed",
+			Cert:   p256,
+			Issuer: p521,
+			ExpectedErrors: []string{
+				"Issuer ECDSA curve P-521 not allowed (key policy allowECDSANISTP521)",
+			},
+		},
+		{
+			Name: "Invalid: certificate key not allowed",
+			Cert: p521,
+			ExpectedErrors: []string{
+				"Certificate ECDSA curve P-521 not allowed (key policy allowECDSANISTP521)",
+			},
+		},
+		{
+			// The key of a self-signed certificate is reported once
+			Name:   "Invalid: self-signed key not allowed",
+			Cert:   p521,
+			Issuer: p521,
+			ExpectedErrors: []string{
+				"Certificate ECDSA curve P-521 not allowed (key policy allowECDSANISTP521)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{Type: "DV", Cert: tc.Cert, Issuer: tc.Issuer}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
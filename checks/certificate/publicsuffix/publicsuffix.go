@@ -7,8 +7,6 @@ import (
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
 	"github.com/globalsign/certlint/errors"
-
-	psl "golang.org/x/net/publicsuffix"
 )
 
 const checkName = "Public Suffix (xTLD) Check"
@@ -25,7 +23,7 @@ func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
 	if len(d.Cert.Subject.CommonName) > 0 {
-		suffix, icann := psl.PublicSuffix(strings.ToLower(d.Cert.Subject.CommonName))
+		suffix, icann := d.PublicSuffix(strings.ToLower(d.Cert.Subject.CommonName))
 		if fmt.Sprintf("*.%s", suffix) == d.Cert.Subject.CommonName || suffix == d.Cert.Subject.CommonName {
 			// if there is a dot on the suffix, it must be on the psl
 			if icann || strings.Count(suffix, ".") > 0 {
@@ -35,7 +33,7 @@ func Check(d *certdata.Data) *errors.Errors {
 	}
 
 	for _, n := range d.Cert.DNSNames {
-		suffix, icann := psl.PublicSuffix(strings.ToLower(n))
+		suffix, icann := d.PublicSuffix(strings.ToLower(n))
 		if fmt.Sprintf("*.%s", suffix) == n || suffix == n {
 			// if there is a dot on the suffix, it must be on the psl
 			if icann || strings.Count(suffix, ".") > 0 {
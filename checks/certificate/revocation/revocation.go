@@ -1,8 +1,6 @@
 package revocation
 
 import (
-	"net/url"
-
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
 	"github.com/globalsign/certlint/errors"
@@ -41,25 +39,8 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
-	// Check CRL information
-	for _, crl := range d.Cert.CRLDistributionPoints {
-		l, err := url.Parse(crl)
-		if err != nil {
-			e.Err("Certificate contains an invalid CRL (%s)", crl)
-		} else if l.Scheme != "http" {
-			e.Err("Certificate contains a CRL with an non-preferred scheme (%s)", l.Scheme)
-		}
-	}
-
-	// Check OCSP information
-	for _, server := range d.Cert.OCSPServer {
-		s, err := url.Parse(server)
-		if err != nil {
-			e.Err("Certificate contains an invalid OCSP server (%s)", s)
-		} else if s.Scheme != "http" {
-			e.Err("Certificate contains a OCSP server with an non-preferred scheme (%s)", s.Scheme)
-		}
-	}
+	// The CRL and OCSP locations are verified by the CRLDistributionPoints and
+	// AuthorityInfoAccess extension checks.
 
 	return e
 }
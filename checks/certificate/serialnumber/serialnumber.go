@@ -11,6 +11,12 @@ import (
 
 const checkName = "Certificate Serial Number Check"
 
+// https://cabforum.org/2016/07/08/ballot-164/
+var ballot164 = checks.Rule{
+	Name:      "Ballot 164",
+	Effective: time.Date(2016, 9, 30, 0, 0, 0, 0, time.UTC),
+}
+
 func init() {
 	checks.RegisterCertificateCheck(checkName, nil, Check)
 }
@@ -28,8 +34,7 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
-	// https://cabforum.org/2016/07/08/ballot-164/
-	if d.Cert.NotBefore.After(time.Date(2016, 9, 30, 0, 0, 0, 0, time.UTC)) {
+	if ballot164.Applies(d) {
 		if d.Cert.SerialNumber.BitLen() < 64 {
 			e.Err("Certificate serial number should be 64 bits but contains %d bits", d.Cert.SerialNumber.BitLen())
 		}
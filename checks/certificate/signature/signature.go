@@ -0,0 +1,68 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+)
+
+const checkName = "Signature Check"
+
+// Signature algorithm that must be used with the curve of an ECDSA issuer key
+// (Mozilla Root Store Policy 5.1.2)
+var curveSignatureAlgorithm = map[string]x509.SignatureAlgorithm{
+	elliptic.P256().Params().Name: x509.ECDSAWithSHA256,
+	elliptic.P384().Params().Name: x509.ECDSAWithSHA384,
+}
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, nil, Check)
+}
+
+// Check verifies the signature of the certificate with the key of the issuer,
+// a self-signed certificate is verified with its own key when no issuer is
+// known. Self-issued certificates that are signed by another key of the CA,
+// e.g. for a key rollover, are skipped without a known issuer.
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	issuer := d.Issuer
+	if issuer == nil && (d.SelfSigned() || sameKeyID(d.Cert)) {
+		issuer = d.Cert
+	}
+	if issuer == nil {
+		return e
+	}
+
+	// Signatures that can't be verified with the algorithm are not reported as
+	// invalid signature
+	err := issuer.CheckSignature(d.Cert.SignatureAlgorithm, d.Cert.RawTBSCertificate, d.Cert.Signature)
+	if _, insecure := err.(x509.InsecureAlgorithmError); insecure || err == x509.ErrUnsupportedAlgorithm {
+		e.Err("Certificate signature can't be verified: %s", err.Error())
+	} else if err != nil {
+		e.Crit("Certificate signature can't be verified with the issuer public key: %s", err.Error())
+	}
+
+	if key, ok := issuer.PublicKey.(*ecdsa.PublicKey); ok {
+		name := key.Curve.Params().Name
+		if alg, ok := curveSignatureAlgorithm[name]; ok && d.Cert.SignatureAlgorithm != alg {
+			e.Err("Certificate is signed with %s, but an issuer %s key MUST sign with %s (Mozilla Root Store Policy 5.1.2)", d.Cert.SignatureAlgorithm, name, alg)
+		}
+	}
+
+	return e
+}
+
+// sameKeyID returns true if a self-issued certificate identifies its own key
+// as the key of the issuer
+func sameKeyID(c *x509.Certificate) bool {
+	if !bytes.Equal(c.RawIssuer, c.RawSubject) {
+		return false
+	}
+	return len(c.AuthorityKeyId) > 0 && bytes.Equal(c.AuthorityKeyId, c.SubjectKeyId)
+}
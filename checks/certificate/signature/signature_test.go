@@ -0,0 +1,140 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// newCertificate returns a certificate for key signed by the issuer, the
+// certificate is self-signed when the issuer is nil.
+func newCertificate(t *testing.T, cn string, key *ecdsa.PrivateKey, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey, alg x509.SignatureAlgorithm) *x509.Certificate {
+	return createCertificate(t, &x509.Certificate{
+		Subject:            pkix.Name{CommonName: cn},
+		SignatureAlgorithm: alg,
+	}, key, issuer, issuerKey)
+}
+
+// createCertificate completes the template with the CA settings and signs it
+func createCertificate(t *testing.T, template *x509.Certificate, key *ecdsa.PrivateKey, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey) *x509.Certificate {
+	template.SerialNumber = big.NewInt(1)
+	template.NotBefore = time.Now()
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.BasicConstraintsValid = true
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageCertSign
+	if issuer == nil {
+		issuer, issuerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newKey(t *testing.T, c elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestCheck(t *testing.T) {
+	p256 := newKey(t, elliptic.P256())
+	p384 := newKey(t, elliptic.P384())
+	leafKey := newKey(t, elliptic.P256())
+
+	root256 := newCertificate(t, "Root", p256, nil, nil, x509.ECDSAWithSHA256)
+	root384 := newCertificate(t, "Root", p384, nil, nil, x509.ECDSAWithSHA384)
+
+	// Self-issued certificate of a new key, signed by the previous key
+	rollover := newCertificate(t, "Root", p384, root256, p256, x509.ECDSAWithSHA256)
+
+	// The key identifiers identify the key of the certificate as the key of
+	// the issuer
+	tampered := createCertificate(t, &x509.Certificate{
+		Subject:            pkix.Name{CommonName: "Root"},
+		SignatureAlgorithm: x509.ECDSAWithSHA256,
+		SubjectKeyId:       []byte{1, 2, 3, 4},
+		AuthorityKeyId:     []byte{1, 2, 3, 4},
+	}, p256, nil, nil)
+	tampered.Signature = append([]byte(nil), tampered.Signature...)
+	tampered.Signature[len(tampered.Signature)-1] ^= 0xff
+
+	testCases := []struct {
+		Name           string
+		Cert           *x509.Certificate
+		Issuer         *x509.Certificate
+		ExpectedErrors []string
+	}{
+		{
+			Name:   "Valid: signed by the issuer",
+			Cert:   newCertificate(t, "Leaf", leafKey, root256, p256, x509.ECDSAWithSHA256),
+			Issuer: root256,
+		},
+		{
+			Name: "Valid: self-signed",
+			Cert: root384,
+		},
+		{
+			Name: "Valid: self-issued key rollover without a known issuer",
+			Cert: rollover,
+		},
+		{
+			Name:   "Invalid: signed by another issuer",
+			Cert:   newCertificate(t, "Leaf", leafKey, root256, p256, x509.ECDSAWithSHA256),
+			Issuer: root384,
+			ExpectedErrors: []string{
+				"Certificate signature can't be verified with the issuer public key: x509: ECDSA verification failure",
+				"Certificate is signed with ECDSA-SHA256, but an issuer P-384 key MUST sign with ECDSA-SHA384 (Mozilla Root Store Policy 5.1.2)",
+			},
+		},
+		{
+			Name: "Invalid: tampered self-signed signature",
+			Cert: tampered,
+			ExpectedErrors: []string{
+				"Certificate signature can't be verified with the issuer public key: x509: ECDSA verification failure",
+			},
+		},
+		{
+			Name:   "Invalid: hash does not match the issuer key",
+			Cert:   newCertificate(t, "Leaf", leafKey, root384, p384, x509.ECDSAWithSHA256),
+			Issuer: root384,
+			ExpectedErrors: []string{
+				"Certificate is signed with ECDSA-SHA256, but an issuer P-384 key MUST sign with ECDSA-SHA384 (Mozilla Root Store Policy 5.1.2)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{Cert: tc.Cert, Issuer: tc.Issuer}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
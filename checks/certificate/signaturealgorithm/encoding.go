@@ -0,0 +1,165 @@
+package signaturealgorithm
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"time"
+
+	asn1lint "github.com/globalsign/certlint/asn1"
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+)
+
+const encodingCheckName = "Algorithm Identifier Encoding Check"
+
+// Mozilla Root Store Policy 2.7 requires byte-for-byte identical encodings
+var mozillaEncodings = checks.Rule{
+	Name:      "Mozilla Root Store Policy 2.7",
+	Effective: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// algorithmEncoding is a permitted DER encoding of an AlgorithmIdentifier
+type algorithmEncoding struct {
+	name string
+	oid  asn1.ObjectIdentifier
+	der  []byte
+}
+
+// Signature algorithms permitted by Mozilla Root Store Policy 5.1.1 and 5.1.2
+var signatureEncodings = []algorithmEncoding{
+	{"sha256WithRSAEncryption", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}, mustDecode("300d06092a864886f70d01010b0500")},
+	{"sha384WithRSAEncryption", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}, mustDecode("300d06092a864886f70d01010c0500")},
+	{"sha512WithRSAEncryption", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}, mustDecode("300d06092a864886f70d01010d0500")},
+	{"RSASSA-PSS", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}, mustDecode("304106092a864886f70d01010a3034a00f300d06096086480165030402010500a11c301a06092a864886f70d010108300d06096086480165030402010500a203020120")},
+	{"RSASSA-PSS", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}, mustDecode("304106092a864886f70d01010a3034a00f300d06096086480165030402020500a11c301a06092a864886f70d010108300d06096086480165030402020500a203020130")},
+	{"RSASSA-PSS", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}, mustDecode("304106092a864886f70d01010a3034a00f300d06096086480165030402030500a11c301a06092a864886f70d010108300d06096086480165030402030500a203020140")},
+	{"ecdsa-with-SHA256", asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}, mustDecode("300a06082a8648ce3d040302")},
+	{"ecdsa-with-SHA384", asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}, mustDecode("300a06082a8648ce3d040303")},
+}
+
+// Public key algorithms permitted by Mozilla Root Store Policy 5.1.1 and 5.1.2
+var publicKeyEncodings = []algorithmEncoding{
+	{"rsaEncryption", asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}, mustDecode("300d06092a864886f70d0101010500")},
+	{"id-ecPublicKey", asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, mustDecode("301306072a8648ce3d020106082a8648ce3d030107")},
+	{"id-ecPublicKey", asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, mustDecode("301006072a8648ce3d020106052b81040022")},
+}
+
+// RSASSA-PSS keys are not permitted in the SubjectPublicKeyInfo
+var oidRSASSAPSS = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+var oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "IV", "EV", "PS", "CA"},
+	}
+	checks.RegisterCertificateCheck(encodingCheckName, filter, CheckEncoding)
+}
+
+// CheckEncoding verifies the encoding of the AlgorithmIdentifiers in the
+// certificate, the encodings are only available for parsed certificates.
+//
+// RFC 5280 4.1.1.2 states:
+//
+//  This field MUST contain the same algorithm identifier as the
+//  signature field in the sequence tbsCertificate (Section 4.1.2.3).
+//
+func CheckEncoding(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	var tbs, outer, spki *asn1.RawValue
+	fields := asn1lint.Certificate.Fields(d.Cert.Raw)
+	for i := range fields {
+		switch fields[i].Path {
+		case "tbsCertificate.signature":
+			tbs = &fields[i].RawValue
+		case "signatureAlgorithm":
+			outer = &fields[i].RawValue
+		case "tbsCertificate.subjectPublicKeyInfo.algorithm":
+			spki = &fields[i].RawValue
+		}
+	}
+	if tbs == nil || outer == nil || spki == nil {
+		return e
+	}
+
+	if !bytes.Equal(tbs.FullBytes, outer.FullBytes) {
+		e.Err("Certificate signatureAlgorithm does not match the tbsCertificate signature (RFC 5280 4.1.1.2)")
+	}
+
+	if !mozillaEncodings.Applies(d) {
+		return e
+	}
+
+	if name, ok := permitted(signatureEncodings, tbs.FullBytes); !ok {
+		if len(name) > 0 {
+			e.Err("Certificate signature algorithm %s is not encoded as required by Mozilla Root Store Policy 5.1", name)
+		} else {
+			e.Err("Certificate signature algorithm %s is not permitted by Mozilla Root Store Policy 5.1", algorithmOID(tbs.FullBytes))
+		}
+	}
+
+	// Other key types are verified by the public key check
+	if name, ok := permitted(publicKeyEncodings, spki.FullBytes); !ok {
+		if curve := namedCurve(spki.FullBytes); curve != nil {
+			e.Err("Certificate public key curve %s is not permitted by Mozilla Root Store Policy 5.1.2", curve)
+		} else if len(name) > 0 {
+			e.Err("Certificate public key algorithm %s is not encoded as required by Mozilla Root Store Policy 5.1", name)
+		} else if algorithmOID(spki.FullBytes).Equal(oidRSASSAPSS) {
+			e.Err("Certificate public key algorithm RSASSA-PSS is not permitted by Mozilla Root Store Policy 5.1.1")
+		}
+	}
+
+	return e
+}
+
+// permitted returns true if der is one of the permitted encodings, if not the
+// name of the algorithm is returned when the algorithm itself is permitted.
+func permitted(encodings []algorithmEncoding, der []byte) (string, bool) {
+	oid := algorithmOID(der)
+
+	var name string
+	for _, a := range encodings {
+		if bytes.Equal(a.der, der) {
+			return a.name, true
+		}
+		if a.oid.Equal(oid) && len(name) == 0 {
+			name = a.name
+		}
+	}
+	return name, false
+}
+
+// algorithmOID returns the algorithm of an encoded AlgorithmIdentifier
+func algorithmOID(der []byte) asn1.ObjectIdentifier {
+	var ai pkix.AlgorithmIdentifier
+	if _, err := asn1.Unmarshal(der, &ai); err != nil {
+		return nil
+	}
+	return ai.Algorithm
+}
+
+// namedCurve returns the named curve of an encoded id-ecPublicKey
+// AlgorithmIdentifier, nil for other algorithms or parameters
+func namedCurve(der []byte) asn1.ObjectIdentifier {
+	var ai pkix.AlgorithmIdentifier
+	if _, err := asn1.Unmarshal(der, &ai); err != nil || !ai.Algorithm.Equal(oidECPublicKey) {
+		return nil
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ai.Parameters.FullBytes, &curve); err != nil {
+		return nil
+	}
+	return curve
+}
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
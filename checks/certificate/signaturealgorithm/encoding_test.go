@@ -0,0 +1,162 @@
+package signaturealgorithm
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// tlv returns the DER encoding of a value with the given identifier octet
+func tlv(id byte, content ...[]byte) []byte {
+	c := bytes.Join(content, nil)
+	return append([]byte{id, byte(len(c))}, c...)
+}
+
+// rawCertificate returns a certificate with the given AlgorithmIdentifiers
+func rawCertificate(signature, signatureAlgorithm, publicKey string) []byte {
+	validity := tlv(0x30, tlv(0x17, []byte("210101000000Z")), tlv(0x17, []byte("220101000000Z")))
+	spki := tlv(0x30, mustDecode(publicKey), tlv(0x03, []byte{0x00, 0x01}))
+	tbs := tlv(0x30, tlv(0xa0, tlv(0x02, []byte{0x02})), tlv(0x02, []byte{0x01}), mustDecode(signature), tlv(0x30), validity, tlv(0x30), spki)
+	return tlv(0x30, tbs, mustDecode(signatureAlgorithm), tlv(0x03, []byte{0x00, 0x01}))
+}
+
+const (
+	sha256WithRSA       = "300d06092a864886f70d01010b0500"
+	sha256WithRSANoNull = "300b06092a864886f70d01010b"
+	sha1WithRSA         = "300d06092a864886f70d0101050500"
+	ecdsaWithSHA256     = "300a06082a8648ce3d040302"
+	ecdsaWithSHA256Null = "300c06082a8648ce3d0403020500"
+	rsaEncryption       = "300d06092a864886f70d0101010500"
+	ecdsaWithSHA512     = "300a06082a8648ce3d040304"
+	ecP256              = "301306072a8648ce3d020106082a8648ce3d030107"
+	ecP521              = "301006072a8648ce3d020106052b81040023"
+	ecNoCurve           = "300906072a8648ce3d0201"
+	rsaPSSKey           = "300b06092a864886f70d01010a"
+)
+
+func TestCheckEncoding(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		Signature          string
+		SignatureAlgorithm string
+		PublicKey          string
+		NotBefore          time.Time
+		ExpectedErrors     []string
+	}{
+		{
+			Name:               "Valid: RSA",
+			Signature:          sha256WithRSA,
+			SignatureAlgorithm: sha256WithRSA,
+			PublicKey:          rsaEncryption,
+		},
+		{
+			Name:               "Valid: ECDSA",
+			Signature:          ecdsaWithSHA256,
+			SignatureAlgorithm: ecdsaWithSHA256,
+			PublicKey:          ecP256,
+		},
+		{
+			Name:               "Invalid: RSA without NULL parameters",
+			Signature:          sha256WithRSANoNull,
+			SignatureAlgorithm: sha256WithRSANoNull,
+			PublicKey:          rsaEncryption,
+			ExpectedErrors: []string{
+				"Certificate signature algorithm sha256WithRSAEncryption is not encoded as required by Mozilla Root Store Policy 5.1",
+			},
+		},
+		{
+			Name:               "Invalid: ECDSA with NULL parameters",
+			Signature:          ecdsaWithSHA256Null,
+			SignatureAlgorithm: ecdsaWithSHA256Null,
+			PublicKey:          ecP256,
+			ExpectedErrors: []string{
+				"Certificate signature algorithm ecdsa-with-SHA256 is not encoded as required by Mozilla Root Store Policy 5.1",
+			},
+		},
+		{
+			Name:               "Invalid: mismatch between the signature fields",
+			Signature:          sha256WithRSA,
+			SignatureAlgorithm: sha256WithRSANoNull,
+			PublicKey:          rsaEncryption,
+			ExpectedErrors: []string{
+				"Certificate signatureAlgorithm does not match the tbsCertificate signature (RFC 5280 4.1.1.2)",
+			},
+		},
+		{
+			Name:               "Invalid: SHA-1",
+			Signature:          sha1WithRSA,
+			SignatureAlgorithm: sha1WithRSA,
+			PublicKey:          rsaEncryption,
+			ExpectedErrors: []string{
+				"Certificate signature algorithm 1.2.840.113549.1.1.5 is not permitted by Mozilla Root Store Policy 5.1",
+			},
+		},
+		{
+			Name:               "Invalid: public key algorithms",
+			Signature:          ecdsaWithSHA256,
+			SignatureAlgorithm: ecdsaWithSHA256,
+			PublicKey:          ecNoCurve,
+			ExpectedErrors: []string{
+				"Certificate public key algorithm id-ecPublicKey is not encoded as required by Mozilla Root Store Policy 5.1",
+			},
+		},
+		{
+			Name:               "Invalid: P-521 with SHA-512",
+			Signature:          ecdsaWithSHA512,
+			SignatureAlgorithm: ecdsaWithSHA512,
+			PublicKey:          ecP521,
+			ExpectedErrors: []string{
+				"Certificate signature algorithm 1.2.840.10045.4.3.4 is not permitted by Mozilla Root Store Policy 5.1",
+				"Certificate public key curve 1.3.132.0.35 is not permitted by Mozilla Root Store Policy 5.1.2",
+			},
+		},
+		{
+			Name:               "Invalid: RSASSA-PSS public key",
+			Signature:          sha256WithRSA,
+			SignatureAlgorithm: sha256WithRSA,
+			PublicKey:          rsaPSSKey,
+			ExpectedErrors: []string{
+				"Certificate public key algorithm RSASSA-PSS is not permitted by Mozilla Root Store Policy 5.1.1",
+			},
+		},
+		{
+			Name:               "Valid: encoding before Mozilla Root Store Policy 2.7",
+			Signature:          sha256WithRSANoNull,
+			SignatureAlgorithm: sha256WithRSANoNull,
+			PublicKey:          rsaEncryption,
+			NotBefore:          time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			notBefore := tc.NotBefore
+			if notBefore.IsZero() {
+				notBefore = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			}
+
+			d := &certdata.Data{
+				Cert: &x509.Certificate{
+					Raw:       rawCertificate(tc.Signature, tc.SignatureAlgorithm, tc.PublicKey),
+					NotBefore: notBefore,
+				},
+				Type: "DV",
+			}
+
+			errList := CheckEncoding(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of CheckEncoding errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
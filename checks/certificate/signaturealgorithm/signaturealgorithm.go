@@ -11,6 +11,20 @@ import (
 
 const checkName = "Signature Algorithm Check"
 
+// SHA-1 certificates MUST NOT be issued from 1 January 2016 (BR 7.1.3)
+var sha1Issuance = checks.Rule{
+	Name:      "BR 7.1.3",
+	Effective: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// SHA-1 certificates issued from 16 January 2015 MUST NOT be valid after
+// 1 January 2017 (BR 7.1.3), the value is the latest expiration date.
+var sha1Validity = checks.Rule{
+	Name:      "BR 7.1.3",
+	Effective: time.Date(2015, 1, 16, 0, 0, 0, 0, time.UTC),
+	Value:     time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
 func init() {
 	filter := &checks.Filter{
 		Type: []string{"DV", "OV", "IV", "EV"},
@@ -29,16 +43,16 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
-	if d.Cert.NotBefore.After(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) {
+	if sha1Issuance.Applies(d) {
 		e.Err("Certificate is using SHA1, but is issued on/after 1 Jan 2016")
 		return e
 	}
 
-	if d.Cert.NotBefore.After(time.Date(2015, 1, 16, 0, 0, 0, 0, time.UTC)) &&
-		d.Cert.NotAfter.After(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)) {
+	if sha1Validity.Applies(d) &&
+		d.ExpirationDate().After(sha1Validity.Value.(time.Time)) {
 		e.Err("Certificate is using SHA1, but is still valid on/after 1 Jan 2017")
 		return e
 	}
 
-	return nil
+	return e
 }
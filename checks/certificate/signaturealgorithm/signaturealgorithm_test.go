@@ -0,0 +1,90 @@
+package signaturealgorithm
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		Name           string
+		Algorithm      x509.SignatureAlgorithm
+		NotBefore      time.Time
+		NotAfter       time.Time
+		AsOf           time.Time
+		ExpectedErrors []string
+	}{
+		{
+			Name:      "Valid: SHA-256",
+			Algorithm: x509.SHA256WithRSA,
+			NotBefore: date(2016, 6),
+			NotAfter:  date(2018, 6),
+		},
+		{
+			Name:      "Valid: SHA-1 expiring before 2017",
+			Algorithm: x509.SHA1WithRSA,
+			NotBefore: date(2015, 6),
+			NotAfter:  date(2016, 12),
+		},
+		{
+			Name:      "Invalid: SHA-1 issued in 2016",
+			Algorithm: x509.SHA1WithRSA,
+			NotBefore: date(2016, 6),
+			NotAfter:  date(2016, 12),
+			ExpectedErrors: []string{
+				"Certificate is using SHA1, but is issued on/after 1 Jan 2016",
+			},
+		},
+		{
+			Name:      "Invalid: SHA-1 valid after 2017",
+			Algorithm: x509.ECDSAWithSHA1,
+			NotBefore: date(2015, 6),
+			NotAfter:  date(2017, 6),
+			ExpectedErrors: []string{
+				"Certificate is using SHA1, but is still valid on/after 1 Jan 2017",
+			},
+		},
+		{
+			Name:      "Invalid: SHA-1 valid after 2017 as of 2015",
+			Algorithm: x509.SHA1WithRSA,
+			NotBefore: date(2014, 6),
+			NotAfter:  date(2016, 12),
+			AsOf:      date(2015, 6),
+			ExpectedErrors: []string{
+				"Certificate is using SHA1, but is still valid on/after 1 Jan 2017",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{
+					SignatureAlgorithm: tc.Algorithm,
+					NotBefore:          tc.NotBefore,
+					NotAfter:           tc.NotAfter,
+				},
+				AsOf: tc.AsOf,
+			}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
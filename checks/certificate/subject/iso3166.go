@@ -0,0 +1,195 @@
+package subject
+
+import "strings"
+
+// userAssignedCountry is the code allowed by BR 7.1.4.2.2 (g) when the
+// subject's country is not represented by an official ISO 3166-1 country code
+const userAssignedCountry = "XX"
+
+// ISO 3166-1 alpha-2 officially assigned country codes
+//
+// https://www.iso.org/iso-3166-country-codes.html
+//
+var iso3166Countries = map[string]bool{}
+
+func init() {
+	for _, c := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ
+		BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ
+		DE DJ DK DM DO DZ
+		EC EE EG EH ER ES ET
+		FI FJ FK FM FO FR
+		GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY
+		HK HM HN HR HT HU
+		ID IE IL IM IN IO IQ IR IS IT
+		JE JM JO JP
+		KE KG KH KI KM KN KP KR KW KY KZ
+		LA LB LC LI LK LR LS LT LU LV LY
+		MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ
+		NA NC NE NF NG NI NL NO NP NR NU NZ
+		OM
+		PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+		QA
+		RE RO RS RU RW
+		SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ
+		TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ
+		UA UG UM US UY UZ
+		VA VC VE VG VI VN VU
+		WF WS
+		YE YT
+		ZA ZM ZW`) {
+		iso3166Countries[c] = true
+	}
+}
+
+// subdivision is an ISO 3166-2 subdivision, the first name is the official
+// name, other names are commonly used alternatives.
+type subdivision struct {
+	Code  string
+	Names []string
+}
+
+// ISO 3166-2 subdivisions of the countries that are validated, countries
+// that are not listed are not checked.
+var iso3166Subdivisions = map[string][]subdivision{
+	"AU": {
+		{"ACT", []string{"Australian Capital Territory"}},
+		{"NSW", []string{"New South Wales"}},
+		{"NT", []string{"Northern Territory"}},
+		{"QLD", []string{"Queensland"}},
+		{"SA", []string{"South Australia"}},
+		{"TAS", []string{"Tasmania"}},
+		{"VIC", []string{"Victoria"}},
+		{"WA", []string{"Western Australia"}},
+	},
+	"CA": {
+		{"AB", []string{"Alberta"}},
+		{"BC", []string{"British Columbia"}},
+		{"MB", []string{"Manitoba"}},
+		{"NB", []string{"New Brunswick"}},
+		{"NL", []string{"Newfoundland and Labrador"}},
+		{"NS", []string{"Nova Scotia"}},
+		{"NT", []string{"Northwest Territories"}},
+		{"NU", []string{"Nunavut"}},
+		{"ON", []string{"Ontario"}},
+		{"PE", []string{"Prince Edward Island"}},
+		{"QC", []string{"Quebec", "Québec"}},
+		{"SK", []string{"Saskatchewan"}},
+		{"YT", []string{"Yukon"}},
+	},
+	"DE": {
+		{"BW", []string{"Baden-Württemberg"}},
+		{"BY", []string{"Bayern", "Bavaria"}},
+		{"BE", []string{"Berlin"}},
+		{"BB", []string{"Brandenburg"}},
+		{"HB", []string{"Bremen"}},
+		{"HH", []string{"Hamburg"}},
+		{"HE", []string{"Hessen", "Hesse"}},
+		{"MV", []string{"Mecklenburg-Vorpommern", "Mecklenburg-Western Pomerania"}},
+		{"NI", []string{"Niedersachsen", "Lower Saxony"}},
+		{"NW", []string{"Nordrhein-Westfalen", "North Rhine-Westphalia"}},
+		{"RP", []string{"Rheinland-Pfalz", "Rhineland-Palatinate"}},
+		{"SL", []string{"Saarland"}},
+		{"SN", []string{"Sachsen", "Saxony"}},
+		{"ST", []string{"Sachsen-Anhalt", "Saxony-Anhalt"}},
+		{"SH", []string{"Schleswig-Holstein"}},
+		{"TH", []string{"Thüringen", "Thuringia"}},
+	},
+	"NL": {
+		{"DR", []string{"Drenthe"}},
+		{"FL", []string{"Flevoland"}},
+		{"FR", []string{"Fryslân", "Friesland"}},
+		{"GE", []string{"Gelderland"}},
+		{"GR", []string{"Groningen"}},
+		{"LI", []string{"Limburg"}},
+		{"NB", []string{"Noord-Brabant", "North Brabant"}},
+		{"NH", []string{"Noord-Holland", "North Holland"}},
+		{"OV", []string{"Overijssel"}},
+		{"UT", []string{"Utrecht"}},
+		{"ZE", []string{"Zeeland"}},
+		{"ZH", []string{"Zuid-Holland", "South Holland"}},
+	},
+	"US": {
+		{"AL", []string{"Alabama"}},
+		{"AK", []string{"Alaska"}},
+		{"AZ", []string{"Arizona"}},
+		{"AR", []string{"Arkansas"}},
+		{"CA", []string{"California"}},
+		{"CO", []string{"Colorado"}},
+		{"CT", []string{"Connecticut"}},
+		{"DE", []string{"Delaware"}},
+		{"FL", []string{"Florida"}},
+		{"GA", []string{"Georgia"}},
+		{"HI", []string{"Hawaii"}},
+		{"ID", []string{"Idaho"}},
+		{"IL", []string{"Illinois"}},
+		{"IN", []string{"Indiana"}},
+		{"IA", []string{"Iowa"}},
+		{"KS", []string{"Kansas"}},
+		{"KY", []string{"Kentucky"}},
+		{"LA", []string{"Louisiana"}},
+		{"ME", []string{"Maine"}},
+		{"MD", []string{"Maryland"}},
+		{"MA", []string{"Massachusetts"}},
+		{"MI", []string{"Michigan"}},
+		{"MN", []string{"Minnesota"}},
+		{"MS", []string{"Mississippi"}},
+		{"MO", []string{"Missouri"}},
+		{"MT", []string{"Montana"}},
+		{"NE", []string{"Nebraska"}},
+		{"NV", []string{"Nevada"}},
+		{"NH", []string{"New Hampshire"}},
+		{"NJ", []string{"New Jersey"}},
+		{"NM", []string{"New Mexico"}},
+		{"NY", []string{"New York"}},
+		{"NC", []string{"North Carolina"}},
+		{"ND", []string{"North Dakota"}},
+		{"OH", []string{"Ohio"}},
+		{"OK", []string{"Oklahoma"}},
+		{"OR", []string{"Oregon"}},
+		{"PA", []string{"Pennsylvania"}},
+		{"RI", []string{"Rhode Island"}},
+		{"SC", []string{"South Carolina"}},
+		{"SD", []string{"South Dakota"}},
+		{"TN", []string{"Tennessee"}},
+		{"TX", []string{"Texas"}},
+		{"UT", []string{"Utah"}},
+		{"VT", []string{"Vermont"}},
+		{"VA", []string{"Virginia"}},
+		{"WA", []string{"Washington"}},
+		{"WV", []string{"West Virginia"}},
+		{"WI", []string{"Wisconsin"}},
+		{"WY", []string{"Wyoming"}},
+		{"DC", []string{"District of Columbia"}},
+		{"AS", []string{"American Samoa"}},
+		{"GU", []string{"Guam"}},
+		{"MP", []string{"Northern Mariana Islands"}},
+		{"PR", []string{"Puerto Rico"}},
+		{"UM", []string{"United States Minor Outlying Islands"}},
+		{"VI", []string{"Virgin Islands, U.S.", "U.S. Virgin Islands"}},
+	},
+}
+
+// validSubdivision returns true if the value is a subdivision of the country
+// by name or by code (with or without the country prefix). The second return
+// value is false when the subdivisions of the country are unknown.
+func validSubdivision(country, value string) (valid, known bool) {
+	subdivisions, known := iso3166Subdivisions[country]
+	if !known {
+		return false, false
+	}
+
+	value = strings.TrimPrefix(value, country+"-")
+	for _, s := range subdivisions {
+		if value == s.Code {
+			return true, true
+		}
+		for _, n := range s.Names {
+			if strings.EqualFold(value, n) {
+				return true, true
+			}
+		}
+	}
+	return false, true
+}
@@ -0,0 +1,77 @@
+package subject
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+)
+
+func TestISO3166Countries(t *testing.T) {
+	if len(iso3166Countries) != 249 {
+		t.Errorf("expected 249 ISO 3166-1 country codes, got %d", len(iso3166Countries))
+	}
+}
+
+func TestCheckCountry(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		DN             []pkix.AttributeTypeAndValue
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: country codes",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: countryName.oid, Value: "XX"},
+				{Type: jurisdictionCountryName.oid, Value: "US"},
+				{Type: jurisdictionStateOrProvinceName.oid, Value: "Delaware"},
+			},
+		},
+		{
+			Name: "Valid: subdivision code",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: jurisdictionCountryName.oid, Value: "NL"},
+				{Type: jurisdictionStateOrProvinceName.oid, Value: "NL-NH"},
+			},
+		},
+		{
+			Name: "Invalid: country codes",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: countryName.oid, Value: "nl"},
+				{Type: countryName.oid, Value: "UK"},
+				{Type: countryName.oid, Value: "NLD"},
+				{Type: jurisdictionCountryName.oid, Value: "XX"},
+			},
+			ExpectedErrors: []string{
+				"countryName 'nl' MUST be in uppercase",
+				"countryName 'UK' is not an ISO 3166-1 country code",
+				"countryName MUST contain the two-letter ISO 3166-1 country code",
+				"jurisdictionCountryName MUST NOT contain the user-assigned code XX",
+			},
+		},
+		{
+			Name: "Invalid: subdivision",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: jurisdictionCountryName.oid, Value: "US"},
+				{Type: jurisdictionStateOrProvinceName.oid, Value: "Ontario"},
+			},
+			ExpectedErrors: []string{
+				"jurisdictionStateOrProvinceName 'Ontario' is not a known ISO 3166-2 subdivision of US",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			errList := checkDN("", tc.DN).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
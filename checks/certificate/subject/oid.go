@@ -81,6 +81,7 @@ var (
 	deltaRevocationList         = object{asn1.ObjectIdentifier{2, 5, 4, 53}, 0}
 	attributeCertificate        = object{asn1.ObjectIdentifier{2, 5, 4, 58}, 0}
 	pseudonym                   = object{asn1.ObjectIdentifier{2, 5, 4, 65}, 0}
+	organizationIdentifier      = object{asn1.ObjectIdentifier{2, 5, 4, 97}, 0}
 
 	emailAddress = object{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}, 255}
 
@@ -0,0 +1,169 @@
+package subject
+
+import (
+	"crypto/x509/pkix"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// Country code used by the LEI and INT registration schemes
+const globalCountry = "XG"
+
+// organizationIdentifier syntax as defined in EV Guidelines 9.2.8:
+//
+//  3 character Registration Scheme identifier
+//  2 character ISO 3166 country code
+//  optional '+' followed by an ISO 3166-2 state or province code
+//  '-' followed by the Registration Reference
+//
+var orgIdentifierSyntax = regexp.MustCompile(`^([A-Z]{3})([A-Z]{2})(?:\+([A-Z0-9]{1,3}))?-(.+)$`)
+
+// National registration scheme as defined in ETSI EN 319 412-1 5.1.4, a 2
+// character ISO 3166 country code followed by ':' and a reference defined by
+// the national scheme, e.g. NL:KVK-12345678. The national schemes are not
+// allowed in TLS certificates.
+var nationalSchemeSyntax = regexp.MustCompile(`^([A-Z]{2}):(.+)$`)
+
+// TLS certificate types, the EV Guidelines syntax and the attribute order of
+// BR 7.1.4.2 only apply to these types
+var tlsTypes = map[string]bool{
+	"DV": true,
+	"OV": true,
+	"IV": true,
+	"EV": true,
+}
+
+// Registration schemes and if the ISO 3166 country code is replaced by XG
+var registrationSchemes = map[string]bool{
+	"NTR": false, // National Trade Register
+	"VAT": false, // Value Added Tax identification number
+	"PSD": false, // PSD2 authorization number
+	"GOV": false, // Government entity
+	"LEI": true,  // Legal Entity Identifier (ISO 17442)
+	"INT": true,  // Intergovernmental organization
+}
+
+// VAT country prefixes that differ from the ISO 3166 country code
+var vatCountries = map[string]string{
+	"EL": "GR", // Greece
+	"XI": "GB", // Northern Ireland
+}
+
+// checkOrganizationIdentifier verifies the syntax of the organizationIdentifier
+// and the consistency with the other subject attributes.
+func checkOrganizationIdentifier(e *errors.Errors, vetting string, dn []pkix.AttributeTypeAndValue, v interface{}) {
+	s, _ := v.(string)
+	if m := nationalSchemeSyntax.FindStringSubmatch(s); m != nil && !tlsTypes[vetting] {
+		if !iso3166Countries[m[1]] {
+			e.Err("organizationIdentifier '%s' is not an ISO 3166-1 country code", m[1])
+		}
+		return
+	}
+
+	m := orgIdentifierSyntax.FindStringSubmatch(s)
+	if m == nil {
+		if tlsTypes[vetting] {
+			e.Err("organizationIdentifier '%s' does not follow the syntax of EV Guidelines 9.2.8", s)
+		} else {
+			e.Err("organizationIdentifier '%s' does not follow the syntax of ETSI EN 319 412-1 5.1.4", s)
+		}
+		return
+	}
+	scheme, country, state, reference := m[1], m[2], m[3], m[4]
+
+	global, ok := registrationSchemes[scheme]
+	if !ok {
+		e.Err("organizationIdentifier contains an unknown registration scheme %s", scheme)
+		return
+	}
+
+	switch {
+	case global:
+		if country != globalCountry {
+			e.Err("organizationIdentifier %s scheme MUST use country code %s", scheme, globalCountry)
+		}
+	case scheme == "VAT" && len(vatCountries[country]) > 0:
+		country = vatCountries[country]
+	case !iso3166Countries[country]:
+		e.Err("organizationIdentifier '%s' is not an ISO 3166-1 country code", country)
+		return
+	}
+
+	if len(state) > 0 {
+		if valid, known := validSubdivision(country, state); known && !valid {
+			e.Warning("organizationIdentifier '%s' is not a known ISO 3166-2 subdivision of %s", state, country)
+		}
+	}
+
+	switch scheme {
+	case "LEI":
+		if !validLEI(reference) {
+			e.Err("organizationIdentifier contains an invalid LEI '%s' (ISO 17442)", reference)
+		}
+	case "VAT":
+		// The VAT number is issued in the country of the subject
+		if c, ok := valueOf(dn, countryName).(string); ok && c != country {
+			e.Err("organizationIdentifier VAT country %s does not match countryName %s", m[2], c)
+		}
+	case "NTR":
+		// The registration is done in the jurisdiction of incorporation
+		if c, ok := valueOf(dn, jurisdictionCountryName).(string); ok && c != country {
+			e.Err("organizationIdentifier NTR country %s does not match jurisdictionCountryName %s", country, c)
+		}
+	}
+}
+
+// validLEI verifies the format and the ISO 7064 MOD 97-10 check digits of a
+// Legal Entity Identifier
+func validLEI(lei string) bool {
+	if len(lei) != 20 {
+		return false
+	}
+
+	// Letters are replaced by two digits (A=10 ... Z=35)
+	var digits strings.Builder
+	for _, c := range lei {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// checkSerialNumber verifies that the EV serialNumber contains one of the
+// forms of EV Guidelines 9.2.6 for the businessCategory of the subject:
+//
+//  the Registration Number assigned by the Incorporating or Registration
+//  Agency, the date of Incorporation or Registration if there is no
+//  Registration Number, or for Government Entities without either of them
+//  language that indicates that the subject is a Government Entity.
+//
+// Registration numbers and dates have no common syntax, both must contain at
+// least one digit that is not a zero.
+func checkSerialNumber(e *errors.Errors, category, v interface{}) {
+	s, _ := v.(string)
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "Government Entity") {
+		if category != "Government Entity" {
+			e.Err("serialNumber 'Government Entity' is only allowed for a Government Entity (EV Guidelines 9.2.6)")
+		}
+		return
+	}
+	if strings.IndexAny(s, "123456789") < 0 {
+		e.Err("serialNumber '%s' does not contain a registration number or date of incorporation (EV Guidelines 9.2.6)", s)
+	}
+}
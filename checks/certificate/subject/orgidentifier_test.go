@@ -0,0 +1,186 @@
+package subject
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+func TestValidLEI(t *testing.T) {
+	testCases := []struct {
+		LEI   string
+		Valid bool
+	}{
+		{"5493001KJTIIGC8Y1R12", true},
+		{"5493001KJTIIGC8Y1R13", false},
+		{"5493001KJTIIGC8Y1R1", false},
+		{"5493001kjtiigc8y1r12", false},
+	}
+
+	for _, tc := range testCases {
+		if valid := validLEI(tc.LEI); valid != tc.Valid {
+			t.Errorf("%s: expected %t, got %t", tc.LEI, tc.Valid, valid)
+		}
+	}
+}
+
+func TestCheckOrganizationIdentifier(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		CertType       string
+		DN             []pkix.AttributeTypeAndValue
+		ExpectedErrors []string
+	}{
+		{
+			Name:     "Valid: organization identifiers",
+			CertType: "DV",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: countryName.oid, Value: "GR"},
+				{Type: jurisdictionCountryName.oid, Value: "US"},
+				{Type: organizationIdentifier.oid, Value: "VATEL-123456789"},
+				{Type: organizationIdentifier.oid, Value: "NTRUS+DE-1234567"},
+				{Type: organizationIdentifier.oid, Value: "LEIXG-5493001KJTIIGC8Y1R12"},
+			},
+		},
+		{
+			Name:     "Invalid: organization identifiers",
+			CertType: "DV",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: countryName.oid, Value: "NL"},
+				{Type: jurisdictionCountryName.oid, Value: "NL"},
+				{Type: organizationIdentifier.oid, Value: "12345678"},
+				{Type: organizationIdentifier.oid, Value: "ABCNL-12345678"},
+				{Type: organizationIdentifier.oid, Value: "NTRQQ-12345678"},
+				{Type: organizationIdentifier.oid, Value: "VATDE-123456789"},
+				{Type: organizationIdentifier.oid, Value: "NTRUS+DE-1234567"},
+				{Type: organizationIdentifier.oid, Value: "NTRNL+QQ-1234567"},
+				{Type: organizationIdentifier.oid, Value: "LEINL-5493001KJTIIGC8Y1R13"},
+			},
+			ExpectedErrors: []string{
+				"organizationIdentifier '12345678' does not follow the syntax of EV Guidelines 9.2.8",
+				"organizationIdentifier contains an unknown registration scheme ABC",
+				"organizationIdentifier 'QQ' is not an ISO 3166-1 country code",
+				"organizationIdentifier VAT country DE does not match countryName NL",
+				"organizationIdentifier NTR country US does not match jurisdictionCountryName NL",
+				"organizationIdentifier 'QQ' is not a known ISO 3166-2 subdivision of NL",
+				"organizationIdentifier LEI scheme MUST use country code XG",
+				"organizationIdentifier contains an invalid LEI '5493001KJTIIGC8Y1R13' (ISO 17442)",
+			},
+		},
+		{
+			Name:     "Valid: ETSI national scheme in S/MIME certificates",
+			CertType: "PS",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: organizationIdentifier.oid, Value: "NL:KVK-12345678"},
+				{Type: organizationIdentifier.oid, Value: "NTRNL-12345678"},
+			},
+		},
+		{
+			Name:     "Invalid: ETSI national scheme",
+			CertType: "PS",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: organizationIdentifier.oid, Value: "QQ:KVK-12345678"},
+				{Type: organizationIdentifier.oid, Value: "12345678"},
+			},
+			ExpectedErrors: []string{
+				"organizationIdentifier 'QQ' is not an ISO 3166-1 country code",
+				"organizationIdentifier '12345678' does not follow the syntax of ETSI EN 319 412-1 5.1.4",
+			},
+		},
+		{
+			Name:     "Invalid: ETSI national scheme in TLS certificates",
+			CertType: "DV",
+			DN: []pkix.AttributeTypeAndValue{
+				{Type: organizationIdentifier.oid, Value: "NL:KVK-12345678"},
+			},
+			ExpectedErrors: []string{
+				"organizationIdentifier 'NL:KVK-12345678' does not follow the syntax of EV Guidelines 9.2.8",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			errList := checkDN(tc.CertType, tc.DN).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSerialNumber(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Category       string
+		SerialNumber   string
+		ExpectedErrors []string
+	}{
+		{
+			Name:         "Valid: registration number",
+			Category:     "Private Organization",
+			SerialNumber: "HRB 12345",
+		},
+		{
+			Name:         "Valid: date of incorporation",
+			Category:     "Private Organization",
+			SerialNumber: "1999-03-22",
+		},
+		{
+			Name:         "Valid: Government Entity",
+			Category:     "Government Entity",
+			SerialNumber: "Government Entity",
+		},
+		{
+			Name:         "Invalid: Government Entity for a Private Organization",
+			Category:     "Private Organization",
+			SerialNumber: "Government Entity",
+			ExpectedErrors: []string{
+				"serialNumber 'Government Entity' is only allowed for a Government Entity (EV Guidelines 9.2.6)",
+			},
+		},
+		{
+			Name:         "Invalid: placeholder",
+			Category:     "Business Entity",
+			SerialNumber: "N/A",
+			ExpectedErrors: []string{
+				"serialNumber 'N/A' does not contain a registration number or date of incorporation (EV Guidelines 9.2.6)",
+			},
+		},
+		{
+			Name:         "Invalid: zero",
+			Category:     "Private Organization",
+			SerialNumber: "000",
+			ExpectedErrors: []string{
+				"serialNumber '000' does not contain a registration number or date of incorporation (EV Guidelines 9.2.6)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var e = errors.New(nil)
+			checkSerialNumber(e, tc.Category, tc.SerialNumber)
+
+			errList := e.List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package subject
+
+import (
+	"encoding/asn1"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/globalsign/certlint/errors"
+)
+
+// ASN.1 tag of the UniversalString type, not defined in encoding/asn1
+const tagUniversalString = 28
+
+// attributeTypeAndValue keeps the raw value to verify the string type
+type attributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// relativeDistinguishedNameSET is decoded as SET because of the name suffix
+type relativeDistinguishedNameSET []attributeTypeAndValue
+
+// Attributes that may only appear once in a Name (BR 7.1.4.2)
+var singleValued = []object{
+	commonName, surname, serialNumber, countryName, localityName,
+	stateOrProvinceName, organizationName, businessCategory, postalCode,
+	givenName, jurisdictionLocalityName, jurisdictionStateOrProvinceName,
+	jurisdictionCountryName, organizationIdentifier,
+}
+
+// Attributes that are encoded as PrintableString (X.520)
+var printableString = []object{
+	countryName, serialNumber, dnQualifier, jurisdictionCountryName,
+}
+
+// Attributes that are encoded as IA5String (PKCS #9)
+var ia5String = []object{
+	emailAddress,
+}
+
+// Attributes that are encoded as DirectoryString (X.520)
+var directoryString = []object{
+	commonName, surname, localityName, stateOrProvinceName, streetAddress,
+	organizationName, organizationalUnitName, title, businessCategory,
+	postalCode, postOfficeBox, givenName, name, pseudonym,
+	organizationIdentifier, jurisdictionLocalityName,
+	jurisdictionStateOrProvinceName,
+}
+
+// Order of the subject attributes in BR 7.1.4.2, attributes that are not
+// listed can be included anywhere.
+var subjectOrder = []object{
+	countryName, stateOrProvinceName, localityName, postalCode,
+	streetAddress, organizationName, surname, givenName,
+	organizationalUnitName, commonName,
+}
+
+// checkRDNSequence performs checks on the encoded Name that are not possible
+// on the flattened attribute list, field is either Subject or Issuer.
+func checkRDNSequence(field, certType string, raw []byte) *errors.Errors {
+	var e = errors.New(nil)
+
+	// Certificates that are not parsed from DER have no raw Name
+	if len(raw) == 0 {
+		return e
+	}
+
+	var rdns []relativeDistinguishedNameSET
+	if rest, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		e.Err("%s can't be decoded: %s", field, err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("%s contains trailing data", field)
+	}
+
+	// RFC 5280 allows multi-valued RDNs and repeated attributes, the BR
+	// requirements only apply to the subject of TLS certificates
+	brSubject := field == "Subject" && tlsTypes[certType]
+
+	seen := make(map[string]bool)
+	var order int
+	var orderReported bool
+	for _, rdn := range rdns {
+		if len(rdn) == 0 {
+			e.Err("%s contains an empty RelativeDistinguishedName", field)
+			continue
+		}
+		if brSubject && len(rdn) > 1 {
+			e.Err("%s contains a multi-valued RelativeDistinguishedName", field)
+		}
+
+		for _, atv := range rdn {
+			attr := attributeName(atv.Type)
+
+			if brSubject && isAttribute(singleValued, atv.Type) {
+				if seen[atv.Type.String()] {
+					e.Err("%s contains more than one %s attribute", field, attr)
+				}
+				seen[atv.Type.String()] = true
+			}
+
+			checkAttributeValue(e, field, attr, atv)
+
+			if brSubject && !orderReported {
+				for i, o := range subjectOrder {
+					if !o.Equal(atv.Type) {
+						continue
+					}
+					if i < order {
+						e.Notice("Subject attribute %s is not in the order of BR 7.1.4.2", attr)
+						orderReported = true
+					}
+					order = i
+				}
+			}
+		}
+	}
+
+	return e
+}
+
+// checkAttributeValue verifies the string type and value of an attribute
+func checkAttributeValue(e *errors.Errors, field, attr string, atv attributeTypeAndValue) {
+	v := atv.Value
+	if v.Class != asn1.ClassUniversal {
+		e.Err("%s %s has an invalid value type", field, attr)
+		return
+	}
+
+	switch {
+	case isAttribute(printableString, atv.Type):
+		if v.Tag != asn1.TagPrintableString {
+			e.Err("%s %s MUST be encoded as PrintableString", field, attr)
+		}
+	case isAttribute(ia5String, atv.Type):
+		if v.Tag != asn1.TagIA5String {
+			e.Err("%s %s MUST be encoded as IA5String", field, attr)
+		}
+	case isAttribute(directoryString, atv.Type):
+		switch v.Tag {
+		case asn1.TagPrintableString, asn1.TagUTF8String:
+		case asn1.TagT61String, tagUniversalString, asn1.TagBMPString:
+			// RFC 5280 4.1.2.4: CAs MUST use either the PrintableString or
+			// UTF8String encoding of DirectoryString, except in the issuer
+			// name for backward compatibility with the subject of the issuer.
+			if field == "Subject" {
+				e.Err("%s %s MUST be encoded as PrintableString or UTF8String (RFC 5280 4.1.2.4)", field, attr)
+			}
+		default:
+			e.Err("%s %s is not encoded as DirectoryString", field, attr)
+		}
+	}
+
+	var s string
+	switch v.Tag {
+	case asn1.TagPrintableString:
+		for _, c := range v.Bytes {
+			if !isPrintable(c) {
+				e.Err("%s %s contains invalid PrintableString characters", field, attr)
+				break
+			}
+		}
+		s = string(v.Bytes)
+	case asn1.TagIA5String:
+		s = string(v.Bytes)
+	case asn1.TagUTF8String:
+		if !utf8.Valid(v.Bytes) {
+			e.Err("%s %s contains invalid UTF8", field, attr)
+			return
+		}
+		s = string(v.Bytes)
+	default:
+		// Other string types are not inspected for whitespace
+		if len(v.Bytes) == 0 {
+			e.Err("%s contains an empty %s attribute", field, attr)
+		}
+		return
+	}
+
+	if len(s) == 0 {
+		e.Err("%s contains an empty %s attribute", field, attr)
+	} else if strings.TrimFunc(s, unicode.IsSpace) != s {
+		e.Warning("%s %s contains leading or trailing whitespace", field, attr)
+	}
+}
+
+// isPrintable returns true for characters of the PrintableString type
+func isPrintable(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte(" '()+,-./:=?", c) > -1
+}
+
+// isAttribute returns true if the attribute type is in the list
+func isAttribute(list []object, oid asn1.ObjectIdentifier) bool {
+	for _, o := range list {
+		if o.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeName returns the name of the attribute type
+func attributeName(oid asn1.ObjectIdentifier) string {
+	for _, a := range attributeNames {
+		if a.object.Equal(oid) {
+			return a.name
+		}
+	}
+	return oid.String()
+}
+
+var attributeNames = []struct {
+	object object
+	name   string
+}{
+	{commonName, "commonName"},
+	{surname, "surname"},
+	{serialNumber, "serialNumber"},
+	{countryName, "countryName"},
+	{localityName, "localityName"},
+	{stateOrProvinceName, "stateOrProvinceName"},
+	{streetAddress, "streetAddress"},
+	{organizationName, "organizationName"},
+	{organizationalUnitName, "organizationalUnitName"},
+	{title, "title"},
+	{businessCategory, "businessCategory"},
+	{postalCode, "postalCode"},
+	{postOfficeBox, "postOfficeBox"},
+	{name, "name"},
+	{givenName, "givenName"},
+	{dnQualifier, "dnQualifier"},
+	{pseudonym, "pseudonym"},
+	{organizationIdentifier, "organizationIdentifier"},
+	{emailAddress, "emailAddress"},
+	{jurisdictionLocalityName, "jurisdictionLocalityName"},
+	{jurisdictionStateOrProvinceName, "jurisdictionStateOrProvinceName"},
+	{jurisdictionCountryName, "jurisdictionCountryName"},
+}
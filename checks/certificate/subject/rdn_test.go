@@ -0,0 +1,133 @@
+package subject
+
+import (
+	"encoding/asn1"
+	"testing"
+)
+
+func atv(o object, tag int, value string) attributeTypeAndValue {
+	return attributeTypeAndValue{Type: o.oid, Value: asn1.RawValue{Tag: tag, Bytes: []byte(value)}}
+}
+
+func TestCheckRDNSequence(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Field          string
+		CertType       string
+		RDNs           []relativeDistinguishedNameSET
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: subject",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(countryName, asn1.TagPrintableString, "NL")},
+				{atv(localityName, asn1.TagUTF8String, "Amsterdam")},
+				{atv(organizationName, asn1.TagUTF8String, "Example B.V.")},
+				{atv(commonName, asn1.TagPrintableString, "www.example.com")},
+			},
+		},
+		{
+			Name:     "Invalid: multi-valued RDN and duplicate attribute",
+			CertType: "DV",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(countryName, asn1.TagPrintableString, "NL")},
+				{
+					atv(commonName, asn1.TagUTF8String, "www.example.com"),
+					atv(commonName, asn1.TagUTF8String, "example.com"),
+				},
+			},
+			ExpectedErrors: []string{
+				"Subject contains a multi-valued RelativeDistinguishedName",
+				"Subject contains more than one commonName attribute",
+			},
+		},
+		{
+			Name:     "Valid: multi-valued RDN of a S/MIME certificate",
+			CertType: "PS",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(countryName, asn1.TagPrintableString, "NL")},
+				{
+					atv(givenName, asn1.TagUTF8String, "John"),
+					atv(surname, asn1.TagUTF8String, "Doe"),
+				},
+				{atv(organizationalUnitName, asn1.TagUTF8String, "Sales")},
+			},
+		},
+		{
+			Name:     "Valid: legacy issuer",
+			Field:    "Issuer",
+			CertType: "DV",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(commonName, asn1.TagT61String, "Example Root CA")},
+				{
+					atv(organizationName, asn1.TagBMPString, "\x00E\x00x"),
+					atv(countryName, asn1.TagPrintableString, "NL"),
+				},
+			},
+		},
+		{
+			Name: "Invalid: string types and values",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(countryName, asn1.TagUTF8String, "NL")},
+				{atv(serialNumber, asn1.TagPrintableString, "12*34")},
+				{atv(organizationName, asn1.TagT61String, "Example")},
+				{atv(organizationalUnitName, asn1.TagUTF8String, "")},
+				{atv(commonName, asn1.TagUTF8String, " www.example.com")},
+			},
+			ExpectedErrors: []string{
+				"Subject countryName MUST be encoded as PrintableString",
+				"Subject serialNumber contains invalid PrintableString characters",
+				"Subject organizationName MUST be encoded as PrintableString or UTF8String (RFC 5280 4.1.2.4)",
+				"Subject contains an empty organizationalUnitName attribute",
+				"Subject commonName contains leading or trailing whitespace",
+			},
+		},
+		{
+			Name:     "Notice: attribute order",
+			CertType: "OV",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(commonName, asn1.TagUTF8String, "www.example.com")},
+				{atv(organizationName, asn1.TagUTF8String, "Example B.V.")},
+				{atv(countryName, asn1.TagPrintableString, "NL")},
+			},
+			ExpectedErrors: []string{
+				"Subject attribute organizationName is not in the order of BR 7.1.4.2",
+			},
+		},
+		{
+			Name:     "Valid: attribute order of a S/MIME certificate",
+			CertType: "PS",
+			RDNs: []relativeDistinguishedNameSET{
+				{atv(commonName, asn1.TagUTF8String, "John Doe")},
+				{atv(organizationName, asn1.TagUTF8String, "Example B.V.")},
+				{atv(countryName, asn1.TagPrintableString, "NL")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := asn1.Marshal(tc.RDNs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			field := tc.Field
+			if field == "" {
+				field = "Subject"
+			}
+
+			errList := checkRDNSequence(field, tc.CertType, raw).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
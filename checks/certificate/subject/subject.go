@@ -2,6 +2,8 @@ package subject
 
 import (
 	"crypto/x509/pkix"
+	"encoding/asn1"
+	"strings"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -10,6 +12,8 @@ import (
 
 const checkName = "Subject Check"
 
+var cabfOrganizationIdentifier = asn1.ObjectIdentifier{2, 23, 140, 3, 1}
+
 func init() {
 	filter := &checks.Filter{
 		//Type: []string{"DV", "OV", "IV", "EV"},
@@ -19,7 +23,18 @@ func init() {
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
-	return checkDN(d.Type, d.Cert.Subject.Names)
+	var e = checkDN(d.Type, d.Cert.Subject.Names)
+	e.Append(checkRDNSequence("Subject", d.Type, d.Cert.RawSubject))
+	e.Append(checkRDNSequence("Issuer", d.Type, d.Cert.RawIssuer))
+
+	// EV Guidelines 9.8.2: the cabfOrganizationIdentifier extension MUST be
+	// present if the subject organizationIdentifier is present
+	if d.Type == "EV" && inDN(d.Cert.Subject.Names, organizationIdentifier) {
+		if _, ok := d.Extension(cabfOrganizationIdentifier); !ok {
+			e.Err("cabfOrganizationIdentifier extension is required when organizationIdentifier is present (EV Guidelines 9.8.2)")
+		}
+	}
+	return e
 }
 
 // Subject Distinguished Name Fields
@@ -102,17 +117,26 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 			}
 
 		// countryName
+		// If the subject's country is not represented by an official ISO 3166-1
+		// country code, the CA MAY specify the ISO 3166-1 user-assigned code of XX
 		case countryName.Equal(n.Type):
-			// TODO: Check against the values in ISO 3166‐1
-			if len(n.Value.(string)) != 2 {
-				e.Err("countryName MUST contain the two-letter ISO 3166-1 country code")
-			}
+			checkCountry(e, "countryName", n.Value, true)
 
-			// jurisdictionCountryName
+		// jurisdictionCountryName
 		case jurisdictionCountryName.Equal(n.Type):
-			// TODO: Check against the values in ISO 3166‐1
-			if len(n.Value.(string)) != 2 {
-				e.Err("jurisdictionCountryName MUST contain the two-letter ISO 3166-1 country code")
+			checkCountry(e, "jurisdictionCountryName", n.Value, false)
+
+		// jurisdictionStateOrProvinceName
+		case jurisdictionStateOrProvinceName.Equal(n.Type):
+			if err := jurisdictionStateOrProvinceName.Valid(n.Value); err != nil {
+				e.Err("jurisdictionStateOrProvinceName %s", err.Error())
+			}
+
+			// Validate the subdivision when the jurisdiction country is known
+			country, _ := valueOf(dn, jurisdictionCountryName).(string)
+			state, _ := n.Value.(string)
+			if valid, known := validSubdivision(country, state); known && !valid {
+				e.Warning("jurisdictionStateOrProvinceName '%s' is not a known ISO 3166-2 subdivision of %s", state, country)
 			}
 
 		// localityName
@@ -195,6 +219,13 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 			if err := serialNumber.Valid(n.Value); err != nil {
 				e.Err("serialNumber %s", err.Error())
 			}
+			if vetting == "EV" {
+				checkSerialNumber(e, valueOf(dn, businessCategory), n.Value)
+			}
+
+		// organizationIdentifier
+		case organizationIdentifier.Equal(n.Type):
+			checkOrganizationIdentifier(e, vetting, dn, n.Value)
 
 		// givenName
 		case givenName.Equal(n.Type):
@@ -212,6 +243,39 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 	return e
 }
 
+// checkCountry verifies that the value is an ISO 3166-1 alpha-2 country code
+func checkCountry(e *errors.Errors, attr string, v interface{}, userAssigned bool) {
+	c, ok := v.(string)
+	if !ok || len(c) != 2 {
+		e.Err("%s MUST contain the two-letter ISO 3166-1 country code", attr)
+		return
+	}
+
+	if c != strings.ToUpper(c) {
+		e.Err("%s '%s' MUST be in uppercase", attr, c)
+		c = strings.ToUpper(c)
+	}
+
+	switch {
+	case iso3166Countries[c]:
+	case c == userAssignedCountry && userAssigned:
+	case c == userAssignedCountry:
+		e.Err("%s MUST NOT contain the user-assigned code %s", attr, c)
+	default:
+		e.Err("%s '%s' is not an ISO 3166-1 country code", attr, c)
+	}
+}
+
+// valueOf returns the value of the first attribute of the given type
+func valueOf(dn []pkix.AttributeTypeAndValue, attr object) interface{} {
+	for _, n := range dn {
+		if attr.Equal(n.Type) {
+			return n.Value
+		}
+	}
+	return nil
+}
+
 func inDN(dn []pkix.AttributeTypeAndValue, attr object) bool {
 	for _, n := range dn {
 		if attr.Equal(n.Type) {
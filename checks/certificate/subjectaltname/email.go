@@ -0,0 +1,220 @@
+package subjectaltname
+
+import (
+	"encoding/asn1"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
+)
+
+// Size limits as defined in RFC 5321 4.5.3.1, the maximum length of a path
+// is 256 octets including the angle brackets.
+const (
+	maxLocalPart = 64
+	maxMailbox   = 254
+)
+
+var (
+	idOnSmtpUTF8Mailbox = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 9}
+	emailAddressOid     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+)
+
+// checkEmailAddresses verifies the rfc822Name and SmtpUTF8Mailbox names in the
+// subjectAltName and the emailAddress attributes in the subject.
+func checkEmailAddresses(e *errors.Errors, d *certdata.Data) {
+	utf8Mailboxes := smtpUTF8Mailboxes(e, d)
+	if len(d.Cert.EmailAddresses) == 0 && len(utf8Mailboxes) == 0 {
+		e.Err("Certificate doesn't contain any subjectAltName")
+		return
+	}
+
+	mailboxes := make(map[string]bool)
+	for _, s := range d.Cert.EmailAddresses {
+		checkMailbox(e, "Certificate subjectAltName", s, false)
+		mailboxes[normalizeMailbox(s)] = true
+	}
+	for _, s := range utf8Mailboxes {
+		checkMailbox(e, "Certificate subjectAltName", s, true)
+		mailboxes[normalizeMailbox(s)] = true
+	}
+
+	// The emailAddress attribute is deprecated, but when present it must be
+	// listed in the subjectAltName as well (RFC 5280 4.1.2.6)
+	for _, n := range d.Cert.Subject.Names {
+		if !n.Type.Equal(emailAddressOid) {
+			continue
+		}
+		s, ok := n.Value.(string)
+		if !ok {
+			e.Err("Certificate subject emailAddress is not a string")
+			continue
+		}
+		checkMailbox(e, "Certificate subject emailAddress", s, false)
+		if !mailboxes[normalizeMailbox(s)] {
+			e.Err("Certificate subject emailAddress '%s' is not listed in subjectAltName", s)
+		}
+	}
+}
+
+// smtpUTF8Mailboxes returns the SmtpUTF8Mailbox otherNames of the
+// subjectAltName as defined in RFC 8398
+func smtpUTF8Mailboxes(e *errors.Errors, d *certdata.Data) []string {
+	gns, err := d.SubjectAltNames()
+	if err != nil {
+		return nil
+	}
+
+	var mailboxes []string
+	for _, gn := range gns {
+		if gn.Tag != certdata.GeneralNameOther {
+			continue
+		}
+		on, err := certdata.ParseOtherName(gn)
+		if err != nil || !on.TypeID.Equal(idOnSmtpUTF8Mailbox) {
+			continue
+		}
+
+		// SmtpUTF8Mailbox ::= UTF8String (SIZE (1..MAX))
+		if on.Value.Class != asn1.ClassUniversal || on.Value.Tag != asn1.TagUTF8String {
+			e.Err("Certificate subjectAltName SmtpUTF8Mailbox is not an UTF8String (RFC 8398 3)")
+			continue
+		}
+		if !utf8.Valid(on.Value.Bytes) {
+			e.Err("Certificate subjectAltName SmtpUTF8Mailbox contains invalid UTF8")
+			continue
+		}
+		mailboxes = append(mailboxes, string(on.Value.Bytes))
+	}
+	return mailboxes
+}
+
+// checkMailbox verifies the syntax of a Mailbox as defined in RFC 5321 4.1.2,
+// extended with UTF-8 characters (RFC 6531 3.3) for a SmtpUTF8Mailbox.
+func checkMailbox(e *errors.Errors, field, s string, utf8Mailbox bool) {
+	i := strings.LastIndex(s, "@")
+	if i < 1 || i == len(s)-1 {
+		e.Err("%s '%s' contains an invalid email address", field, s)
+		return
+	}
+	local, domain := s[:i], s[i+1:]
+
+	if utf8Mailbox {
+		// RFC 8398 3: SmtpUTF8Mailbox MUST NOT be used unless the local-part
+		// contains non-ASCII characters.
+		if isASCII(local) {
+			e.Err("%s '%s' contains an ASCII local part, rfc822Name must be used instead of SmtpUTF8Mailbox (RFC 8398 3)", field, s)
+		}
+		for _, label := range strings.Split(strings.ToLower(domain), ".") {
+			if strings.HasPrefix(label, "xn--") {
+				e.Err("%s '%s' contains an A-label, SmtpUTF8Mailbox domains must use U-labels (RFC 8398 3)", field, s)
+				break
+			}
+		}
+	} else if !isASCII(s) {
+		e.Err("%s '%s' contains non-ASCII characters, internationalized email addresses must use SmtpUTF8Mailbox (RFC 8398)", field, s)
+	}
+
+	if len(local) > maxLocalPart {
+		e.Err("%s '%s' local part exceeds %d octets (RFC 5321 4.5.3.1.1)", field, s, maxLocalPart)
+	}
+	if len(s) > maxMailbox {
+		e.Err("%s '%s' exceeds %d octets (RFC 5321 4.5.3.1.3)", field, s, maxMailbox)
+	}
+
+	if strings.HasPrefix(local, "\"") {
+		if !isQuotedString(local) {
+			e.Err("%s '%s' contains an invalid local part", field, s)
+		} else {
+			e.Warning("%s '%s' SHOULD NOT use a quoted local part (RFC 5321 4.1.2)", field, s)
+		}
+	} else if !isDotAtom(local) {
+		e.Err("%s '%s' contains an invalid local part", field, s)
+	}
+
+	if strings.HasPrefix(domain, "[") {
+		e.Err("%s '%s' contains an address literal instead of a domain", field, s)
+		return
+	}
+
+	// Check email address domain part
+	if _, err := idnaProfile.ToASCII(strings.ToLower(domain)); err != nil {
+		e.Err("%s '%s', %s", field, s, err.Error())
+	}
+}
+
+// normalizeMailbox returns the mailbox with the domain in lowercase A-labels,
+// the local part is case sensitive (RFC 5321 2.4).
+func normalizeMailbox(s string) string {
+	i := strings.LastIndex(s, "@")
+	if i < 0 {
+		return s
+	}
+	domain := strings.ToLower(s[i+1:])
+	if ascii, err := idnaProfile.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+	return s[:i+1] + domain
+}
+
+// isDotAtom returns true if s is a Dot-string (RFC 5321 4.1.2), non-ASCII
+// characters are allowed as atext (RFC 6531 3.3).
+func isDotAtom(s string) bool {
+	for _, atom := range strings.Split(s, ".") {
+		if len(atom) == 0 {
+			return false
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isQuotedString returns true if s is a Quoted-string (RFC 5321 4.1.2)
+func isQuotedString(s string) bool {
+	if len(s) < 2 || !strings.HasSuffix(s, "\"") {
+		return false
+	}
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			// quoted-pairSMTP = %d92 %d32-126
+			i++
+			if i == len(s) || s[i] < 32 || s[i] > 126 {
+				return false
+			}
+		case c == '"':
+			return false
+		case c < 32 || c == 127:
+			// qtextSMTP = %d32-33 / %d35-91 / %d93-126 or UTF8-non-ascii
+			return false
+		}
+	}
+	return true
+}
+
+// isAtext returns true for the characters allowed in an Atom (RFC 5322 3.2.3)
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case r >= utf8.RuneSelf:
+		return r != utf8.RuneError
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
+
+// isASCII returns true if s only contains ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
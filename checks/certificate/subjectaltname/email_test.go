@@ -0,0 +1,148 @@
+package subjectaltname
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// smtpUTF8Extension returns a subjectAltName extension with SmtpUTF8Mailbox
+// otherNames
+func smtpUTF8Extension(t *testing.T, mailboxes ...string) pkix.Extension {
+	var gns []asn1.RawValue
+	for _, m := range mailboxes {
+		value, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte(m)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// asn1.Marshal ignores the explicit tag of a RawValue
+		b, err := asn1.MarshalWithParams(struct {
+			TypeID asn1.ObjectIdentifier
+			Value  asn1.RawValue
+		}{
+			TypeID: idOnSmtpUTF8Mailbox,
+			Value:  asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: value},
+		}, "tag:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		gns = append(gns, asn1.RawValue{FullBytes: b})
+	}
+	b, err := asn1.Marshal(gns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 17}, Value: b}
+}
+
+func TestCheckEmailAddresses(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		EmailAddresses []string
+		UTF8Mailboxes  []string
+		SubjectEmail   []string
+		ExpectedErrors []string
+	}{
+		{
+			Name:           "Valid: dot-atom local parts",
+			EmailAddresses: []string{"john.doe@example.com", "john+tag@EXAMPLE.com", "o'reilly@xn--9dbq2a.com"},
+			SubjectEmail:   []string{"john.doe@example.COM"},
+		},
+		{
+			Name:           "Invalid: local parts",
+			EmailAddresses: []string{"john..doe@example.com", ".john@example.com", "john doe@example.com", "john@", "@example.com", "john.doe@[192.0.2.1]"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'john..doe@example.com' contains an invalid local part",
+				"Certificate subjectAltName '.john@example.com' contains an invalid local part",
+				"Certificate subjectAltName 'john doe@example.com' contains an invalid local part",
+				"Certificate subjectAltName 'john@' contains an invalid email address",
+				"Certificate subjectAltName '@example.com' contains an invalid email address",
+				"Certificate subjectAltName 'john.doe@[192.0.2.1]' contains an address literal instead of a domain",
+			},
+		},
+		{
+			Name:           "Warning: quoted local part",
+			EmailAddresses: []string{`"john doe"@example.com`, `"john"doe"@example.com`},
+			ExpectedErrors: []string{
+				`Certificate subjectAltName '"john doe"@example.com' SHOULD NOT use a quoted local part (RFC 5321 4.1.2)`,
+				`Certificate subjectAltName '"john"doe"@example.com' contains an invalid local part`,
+			},
+		},
+		{
+			Name:           "Invalid: local part too long",
+			EmailAddresses: []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa@example.com"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa@example.com' local part exceeds 64 octets (RFC 5321 4.5.3.1.1)",
+			},
+		},
+		{
+			Name:           "Invalid: non-ASCII rfc822Name",
+			EmailAddresses: []string{"jöhn@example.com"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'jöhn@example.com' contains non-ASCII characters, internationalized email addresses must use SmtpUTF8Mailbox (RFC 8398)",
+			},
+		},
+		{
+			Name:          "Valid: SmtpUTF8Mailbox",
+			UTF8Mailboxes: []string{"jöhn@例え.jp", "jöhn@example.com"},
+		},
+		{
+			Name:          "Invalid: non-ASCII subject emailAddress",
+			UTF8Mailboxes: []string{"jöhn@例え.jp"},
+			SubjectEmail:  []string{"jöhn@xn--r8jz45g.jp"},
+			ExpectedErrors: []string{
+				"Certificate subject emailAddress 'jöhn@xn--r8jz45g.jp' contains non-ASCII characters, internationalized email addresses must use SmtpUTF8Mailbox (RFC 8398)",
+			},
+		},
+		{
+			Name:          "Invalid: SmtpUTF8Mailbox with ASCII local part and A-label",
+			UTF8Mailboxes: []string{"john@xn--r8jz45g.jp"},
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'john@xn--r8jz45g.jp' contains an ASCII local part, rfc822Name must be used instead of SmtpUTF8Mailbox (RFC 8398 3)",
+				"Certificate subjectAltName 'john@xn--r8jz45g.jp' contains an A-label, SmtpUTF8Mailbox domains must use U-labels (RFC 8398 3)",
+			},
+		},
+		{
+			Name:           "Invalid: subject emailAddress not in subjectAltName",
+			EmailAddresses: []string{"john.doe@example.com"},
+			SubjectEmail:   []string{"John.Doe@example.com"},
+			ExpectedErrors: []string{
+				"Certificate subject emailAddress 'John.Doe@example.com' is not listed in subjectAltName",
+			},
+		},
+		{
+			Name: "Invalid: no email address",
+			ExpectedErrors: []string{
+				"Certificate doesn't contain any subjectAltName",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cert := &x509.Certificate{EmailAddresses: tc.EmailAddresses}
+			if len(tc.UTF8Mailboxes) > 0 {
+				cert.Extensions = append(cert.Extensions, smtpUTF8Extension(t, tc.UTF8Mailboxes...))
+			}
+			for _, s := range tc.SubjectEmail {
+				cert.Subject.Names = append(cert.Subject.Names, pkix.AttributeTypeAndValue{Type: emailAddressOid, Value: s})
+			}
+
+			errList := Check(&certdata.Data{Cert: cert, Type: "PS"}).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -37,29 +37,7 @@ func Check(d *certdata.Data) *errors.Errors {
 
 	switch d.Type {
 	case "PS":
-		// TODO: Check EmailAddresses in the Subject DN
-		if len(d.Cert.EmailAddresses) == 0 {
-			e.Err("Certificate doesn't contain any subjectAltName")
-			return e
-		}
-		for _, s := range d.Cert.EmailAddresses {
-			// Splitting domain of mail address, using lowercase
-			em := strings.SplitAfter(strings.ToLower(s), "@")
-			if len(em) != 2 {
-				e.Err("Certificate subjectAltName '%s' contains an invalid email address", s)
-				continue
-			}
-
-			// Check email address domain part
-			if _, err := idnaProfile.ToASCII(em[1]); err != nil {
-				e.Err("Certificate subjectAltName '%s', %s", s, err.Error())
-			}
-
-			// TODO: Implement more checks for the left side of the @ sign
-			if strings.Contains(em[0], " ") {
-				e.Err("Certificate subjectAltName '%s' contains a whitespace", s)
-			}
-		}
+		checkEmailAddresses(e, d)
 
 	case "DV", "OV", "EV":
 		if len(d.Cert.DNSNames) == 0 && len(d.Cert.IPAddresses) == 0 {
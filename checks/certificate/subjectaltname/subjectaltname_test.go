@@ -32,7 +32,6 @@ func TestSubjectAltNameDNSNames(t *testing.T) {
 	}
 }
 
-// TODO: Set EmailAddresses in the Subject DN
 func TestSubjectAltNameEmailAddresses(t *testing.T) {
 	cd := &certdata.Data{
 		Cert: &x509.Certificate{
@@ -44,9 +43,10 @@ func TestSubjectAltNameEmailAddresses(t *testing.T) {
 		Type: "PS",
 	}
 
+	// Internationalized domains are not allowed in an rfc822Name
 	e := Check(cd)
-	if len(e.List()) != 6 {
-		t.Errorf("Expected 6 errors, got %d", len(e.List()))
+	if len(e.List()) != 9 {
+		t.Errorf("Expected 9 errors, got %d", len(e.List()))
 	}
 	for _, err := range e.List() {
 		fmt.Println(err)
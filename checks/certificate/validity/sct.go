@@ -0,0 +1,66 @@
+package validity
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"time"
+)
+
+// Embedded SCT list extension (RFC 6962 3.3)
+var sctListOid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// earliestSCT returns the earliest timestamp of the embedded SCTs
+func earliestSCT(c *x509.Certificate) (time.Time, bool) {
+	var earliest time.Time
+	for _, ts := range sctTimestamps(c) {
+		if earliest.IsZero() || ts.Before(earliest) {
+			earliest = ts
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// sctTimestamps returns the timestamps of the embedded SCTs, the list is TLS
+// encoded inside an OCTET STRING:
+//
+//  opaque SerializedSCT<1..2^16-1>;
+//  struct {
+//      SerializedSCT sct_list <1..2^16-1>;
+//  } SignedCertificateTimestampList;
+//
+func sctTimestamps(c *x509.Certificate) []time.Time {
+	for _, ext := range c.Extensions {
+		if !ext.Id.Equal(sctListOid) {
+			continue
+		}
+
+		var list []byte
+		if _, err := asn1.Unmarshal(ext.Value, &list); err != nil || len(list) < 2 {
+			return nil
+		}
+		if int(binary.BigEndian.Uint16(list)) != len(list)-2 {
+			return nil
+		}
+		list = list[2:]
+
+		var timestamps []time.Time
+		for len(list) >= 2 {
+			n := int(binary.BigEndian.Uint16(list))
+			if len(list) < 2+n {
+				return timestamps
+			}
+			sct := list[2 : 2+n]
+			list = list[2+n:]
+
+			// version (1), log id (32) and timestamp (8) in milliseconds
+			if len(sct) < 41 || sct[0] != 0 {
+				continue
+			}
+			ms := int64(binary.BigEndian.Uint64(sct[33:41]))
+			timestamps = append(timestamps, time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC())
+		}
+		return timestamps
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package validity
 
 import (
+	"sync"
 	"time"
 
+	asn1lint "github.com/globalsign/certlint/asn1"
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
 	"github.com/globalsign/certlint/errors"
@@ -10,44 +12,125 @@ import (
 
 const checkName = "Validity Check"
 
+var (
+	mu sync.RWMutex
+
+	// Maximum period that notBefore may precede the earliest SCT
+	backdateWindow = 48 * time.Hour
+)
+
+// lifetime is the maximum validity period in days or months
+type lifetime struct {
+	days   int
+	months int
+}
+
+// Maximum validity period of subscriber certificates (BR 6.3.2)
+var lifetimeRules = checks.Rules{
+	{Name: "BR 6.3.2", Sunset: date(2016, 7, 1), Value: lifetime{months: 60}},
+	{Name: "BR 6.3.2", Effective: date(2016, 7, 1), Sunset: date(2018, 3, 1), Value: lifetime{months: 39}},
+	{Name: "Ballot 193", Effective: date(2018, 3, 1), Sunset: date(2020, 9, 1), Value: lifetime{days: 825}},
+	{Name: "Ballot SC31", Effective: date(2020, 9, 1), Sunset: date(2026, 3, 15), Value: lifetime{days: 398}},
+	{Name: "Ballot SC-081", Effective: date(2026, 3, 15), Sunset: date(2027, 3, 15), Value: lifetime{days: 200}},
+	{Name: "Ballot SC-081", Effective: date(2027, 3, 15), Sunset: date(2029, 3, 15), Value: lifetime{days: 100}},
+	{Name: "Ballot SC-081", Effective: date(2029, 3, 15), Value: lifetime{days: 47}},
+}
+
+// Maximum validity period of EV certificates (EV Guidelines 9.4), from
+// September 2020 the BR limit applies.
+var evLifetimeRules = checks.Rules{
+	{Name: "EV Guidelines 9.4", Sunset: date(2017, 3, 17), Value: lifetime{months: 27}},
+	{Name: "Ballot 193", Effective: date(2017, 3, 17), Sunset: date(2020, 9, 1), Value: lifetime{days: 825}},
+	{Name: "Ballot SC31", Effective: date(2020, 9, 1), Sunset: date(2026, 3, 15), Value: lifetime{days: 398}},
+	{Name: "Ballot SC-081", Effective: date(2026, 3, 15), Sunset: date(2027, 3, 15), Value: lifetime{days: 200}},
+	{Name: "Ballot SC-081", Effective: date(2027, 3, 15), Sunset: date(2029, 3, 15), Value: lifetime{days: 100}},
+	{Name: "Ballot SC-081", Effective: date(2029, 3, 15), Value: lifetime{days: 47}},
+}
+
 func init() {
 	checks.RegisterCertificateCheck(checkName, nil, Check)
 }
 
+// SetBackdateWindow configures the maximum period that notBefore may precede
+// the earliest embedded SCT timestamp, certificates without embedded SCTs are
+// not evaluated.
+func SetBackdateWindow(window time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	backdateWindow = window
+}
+
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// The encoding is only available for parsed certificates
+	for _, field := range []string{"notBefore", "notAfter"} {
+		if v, err := asn1lint.Certificate.Lookup(d.Cert.Raw, "tbsCertificate.validity."+field); err == nil {
+			e.Append(asn1lint.CheckTime(field, v))
+		}
+	}
+
+	checkBackdate(e, d)
+
+	var rules checks.Rules
+	var prefix string
 	switch d.Type {
 	case "EV":
-		if d.Cert.NotBefore.After(time.Date(2017, 3, 17, 0, 0, 0, 0, time.UTC)) {
-			if d.Cert.NotAfter.After(d.Cert.NotBefore.AddDate(0, 0, 825)) {
-				e.Err("EV Certificate LifeTime exceeds 825 days")
-				return e
-			}
-		} else {
-			if d.Cert.NotAfter.After(d.Cert.NotBefore.AddDate(0, 27, 0)) {
-				e.Err("EV Certificate LifeTime exceeds 27 months")
-				return e
-			}
-		}
+		rules, prefix = evLifetimeRules, "EV "
 	case "DV", "OV":
-		if d.Cert.NotBefore.After(time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)) {
-			if d.Cert.NotAfter.After(d.Cert.NotBefore.AddDate(0, 0, 825)) {
-				e.Err("Certificate LifeTime exceeds 825 days")
-				return e
-			}
-		} else if d.Cert.NotBefore.After(time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)) {
-			if d.Cert.NotAfter.After(d.Cert.NotBefore.AddDate(0, 39, 0)) {
-				e.Err("Certificate LifeTime exceeds 39 months")
-				return e
-			}
-		} else {
-			if d.Cert.NotAfter.After(d.Cert.NotBefore.AddDate(0, 60, 0)) {
-				e.Err("Certificate LifeTime exceeds 60 months")
-				return e
-			}
+		rules = lifetimeRules
+	default:
+		return e
+	}
+
+	if rule, ok := rules.Applicable(d); ok {
+		l := rule.Value.(lifetime)
+		if l.days > 0 && exceedsDays(d, l.days) {
+			e.Err("%sCertificate LifeTime exceeds %d days", prefix, l.days)
+		}
+		if l.months > 0 && exceedsMonths(d, l.months) {
+			e.Err("%sCertificate LifeTime exceeds %d months", prefix, l.months)
 		}
 	}
+
 	return e
 }
+
+// exceedsDays returns true if the validity period is longer than the given
+// number of days. BR 1.6.1 defines the validity period from notBefore through
+// notAfter inclusive, a notAfter of notBefore plus 398 days is one second too
+// long.
+func exceedsDays(d *certdata.Data, days int) bool {
+	period := d.Cert.NotAfter.Sub(d.Cert.NotBefore) + time.Second
+	return period > time.Duration(days)*24*time.Hour
+}
+
+// exceedsMonths returns true if the inclusive validity period is longer than
+// the given number of months.
+func exceedsMonths(d *certdata.Data, months int) bool {
+	return d.Cert.NotAfter.Add(time.Second).After(d.Cert.NotBefore.AddDate(0, months, 0))
+}
+
+// checkBackdate verifies that notBefore does not precede the issuance of the
+// certificate by more than the backdate window, the earliest embedded SCT is
+// used as an upper bound of the issuance date. The actual issuance date is not
+// part of the certificate, without embedded SCTs nothing is reported.
+func checkBackdate(e *errors.Errors, d *certdata.Data) {
+	issued, ok := earliestSCT(d.Cert)
+	if !ok {
+		return
+	}
+
+	mu.RLock()
+	window := backdateWindow
+	mu.RUnlock()
+
+	if backdate := issued.Sub(d.Cert.NotBefore); backdate > window {
+		e.Warning("notBefore is backdated %s before the earliest SCT, more than the allowed %s", backdate, window)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
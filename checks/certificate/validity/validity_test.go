@@ -0,0 +1,146 @@
+package validity
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// sctList returns the embedded SCT list extension with SCTs at the given times
+func sctList(t *testing.T, times ...time.Time) pkix.Extension {
+	var list []byte
+	for _, ts := range times {
+		sct := make([]byte, 2+41)
+		binary.BigEndian.PutUint16(sct, 41)
+		binary.BigEndian.PutUint64(sct[2+33:], uint64(ts.UnixNano()/int64(time.Millisecond)))
+		list = append(list, sct...)
+	}
+	list = append([]byte{byte(len(list) >> 8), byte(len(list))}, list...)
+
+	b, err := asn1.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: sctListOid, Value: b}
+}
+
+func TestCheck(t *testing.T) {
+	notBefore := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Name           string
+		CertType       string
+		NotBefore      time.Time
+		NotAfter       time.Time
+		AsOf           time.Time
+		Extensions     []pkix.Extension
+		ExpectedErrors []string
+	}{
+		{
+			Name:      "Valid: 398 days inclusive",
+			CertType:  "DV",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.AddDate(0, 0, 398).Add(-time.Second),
+		},
+		{
+			Name:      "Invalid: one second over 398 days",
+			CertType:  "OV",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.AddDate(0, 0, 398),
+			ExpectedErrors: []string{
+				"Certificate LifeTime exceeds 398 days",
+			},
+		},
+		{
+			Name:      "Invalid: EV over 398 days",
+			CertType:  "EV",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.AddDate(0, 0, 400),
+			ExpectedErrors: []string{
+				"EV Certificate LifeTime exceeds 398 days",
+			},
+		},
+		{
+			Name:      "Invalid: over 200 days after SC-081",
+			CertType:  "DV",
+			NotBefore: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+			NotAfter:  time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 200),
+			ExpectedErrors: []string{
+				"Certificate LifeTime exceeds 200 days",
+			},
+		},
+		{
+			Name:      "Valid: 47 days in 2029",
+			CertType:  "DV",
+			NotBefore: time.Date(2029, 3, 15, 0, 0, 0, 0, time.UTC),
+			NotAfter:  time.Date(2029, 3, 15, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 47).Add(-time.Second),
+		},
+		{
+			Name:      "Invalid: 398 days as of SC-081",
+			CertType:  "DV",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.AddDate(0, 0, 398).Add(-time.Second),
+			AsOf:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedErrors: []string{
+				"Certificate LifeTime exceeds 200 days",
+			},
+		},
+		{
+			Name:      "Invalid: one second over 825 days",
+			CertType:  "DV",
+			NotBefore: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:  time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 825),
+			ExpectedErrors: []string{
+				"Certificate LifeTime exceeds 825 days",
+			},
+		},
+		{
+			Name:       "Valid: notBefore within the backdate window",
+			CertType:   "DV",
+			NotBefore:  notBefore,
+			NotAfter:   notBefore.AddDate(0, 0, 90),
+			Extensions: []pkix.Extension{sctList(t, notBefore.Add(time.Hour), notBefore.Add(2*time.Hour))},
+		},
+		{
+			Name:       "Invalid: notBefore backdated",
+			CertType:   "DV",
+			NotBefore:  notBefore,
+			NotAfter:   notBefore.AddDate(0, 0, 90),
+			Extensions: []pkix.Extension{sctList(t, notBefore.AddDate(0, 0, 10), notBefore.AddDate(0, 0, 3))},
+			ExpectedErrors: []string{
+				"notBefore is backdated 72h0m0s before the earliest SCT, more than the allowed 48h0m0s",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{
+					NotBefore:  tc.NotBefore,
+					NotAfter:   tc.NotAfter,
+					Extensions: tc.Extensions,
+				},
+				Type: tc.CertType,
+				AsOf: tc.AsOf,
+			}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package wildcard
 
 import (
+	"net"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -10,34 +13,116 @@ import (
 
 const checkName = "Wildcard(s) Check"
 
+var (
+	mu              sync.RWMutex
+	privateSuffixes = true
+)
+
+// Wildcards are allowed for onion domain names in EV certificates since
+// Ballot 144 (EV Guidelines Appendix F)
+var evOnionWildcard = checks.Rule{
+	Name:      "Ballot 144",
+	Effective: time.Date(2015, 2, 18, 0, 0, 0, 0, time.UTC),
+}
+
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "IV", "EV"},
+	}
+	checks.RegisterCertificateCheck(checkName, filter, Check)
+}
+
+// SetPrivateSuffixes configures if a wildcard directly above a suffix from the
+// private section of the public suffix list is reported, suffixes from the
+// ICANN section are always reported.
+func SetPrivateSuffixes(report bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	privateSuffixes = report
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	switch d.Type {
-	case "EV":
-		if strings.LastIndex(d.Cert.Subject.CommonName, "*") > -1 {
+	if strings.Contains(d.Cert.Subject.CommonName, "*") {
+		if d.Type == "EV" && !evWildcardAllowed(d, d.Cert.Subject.CommonName) {
 			e.Err("Certificate should not contain a wildcard")
+		} else {
+			checkWildcard(e, d, "Certificate common name", d.Cert.Subject.CommonName)
 		}
-		for _, n := range d.Cert.DNSNames {
-			if strings.LastIndex(n, "*") > -1 {
-				e.Err("Certificate subjectAltName '%s' should not contain a wildcard", n)
-			}
+	}
+
+	for _, n := range d.Cert.DNSNames {
+		if !strings.Contains(n, "*") {
+			continue
 		}
-	case "DV", "OV":
-		if strings.LastIndex(d.Cert.Subject.CommonName, "*") > 0 {
-			e.Err("Certificate wildcard is only allowed as prefix")
+
+		// EV Guidelines 9.8.1 and Appendix F, wildcards are not allowed in EV
+		// certificates unless for a Tor onion address
+		if d.Type == "EV" && !evWildcardAllowed(d, n) {
+			e.Err("Certificate subjectAltName '%s' should not contain a wildcard", n)
+			continue
 		}
-		for _, n := range d.Cert.DNSNames {
-			if strings.LastIndex(n, "*") > 0 {
-				e.Err("Certificate subjectAltName '%s' wildcard is only allowed as prefix", n)
-			}
+		checkWildcard(e, d, "Certificate subjectAltName", n)
+	}
+
+	// Wildcards are only defined for domain names (RFC 6125 6.4.3)
+	for _, n := range d.Cert.EmailAddresses {
+		if strings.Contains(n, "*") {
+			e.Err("Certificate subjectAltName '%s' contains a wildcard in an email address", n)
 		}
 	}
 
 	return e
 }
+
+// checkWildcard verifies that the wildcard is the entire left-most label of
+// a domain name and that it's not directly above a public suffix (BR 3.2.2.6)
+func checkWildcard(e *errors.Errors, d *certdata.Data, field, name string) {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	base := strings.Join(labels[1:], ".")
+
+	if net.ParseIP(strings.Replace(name, "*", "0", -1)) != nil {
+		e.Err("%s '%s' contains a wildcard in an IP address", field, name)
+		return
+	}
+	if strings.Count(name, "*") > 1 {
+		e.Err("%s '%s' contains more than one wildcard", field, name)
+		return
+	}
+	if labels[0] != "*" {
+		e.Err("%s '%s' wildcard is only allowed as the entire left-most label", field, name)
+		return
+	}
+	if len(base) == 0 {
+		e.Err("%s '%s' wildcard is not followed by a domain name", field, name)
+		return
+	}
+
+	suffix, icann := d.PublicSuffix(strings.ToLower(base))
+	if suffix != strings.ToLower(base) {
+		return
+	}
+
+	mu.RLock()
+	private := privateSuffixes
+	mu.RUnlock()
+
+	// Without a rule the last label is returned, only registered suffixes are
+	// reported.
+	if icann || (private && strings.Contains(suffix, ".")) {
+		e.Err("%s '%s' wildcard is directly above the public suffix %q", field, name, suffix)
+	}
+}
+
+// evWildcardAllowed returns true if the EV Guidelines allow a wildcard for the
+// name at the issuance date of the certificate
+func evWildcardAllowed(d *certdata.Data, name string) bool {
+	return isOnion(name) && evOnionWildcard.Applies(d)
+}
+
+// isOnion returns true for a Tor onion address
+func isOnion(name string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSuffix(name, ".")), ".onion")
+}
@@ -0,0 +1,133 @@
+package wildcard
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		CommonName     string
+		DNSNames       []string
+		EmailAddresses []string
+		CertType       string
+		NotBefore      time.Time
+		ExpectedErrors []string
+	}{
+		{
+			Name:       "Valid: wildcard as left-most label",
+			CommonName: "*.example.com",
+			DNSNames:   []string{"*.example.com", "example.com", "*.example.co.uk"},
+			CertType:   "OV",
+		},
+		{
+			Name:     "Invalid: partial label wildcard",
+			DNSNames: []string{"f*o.example.com", "*foo.example.com", "www.*.example.com"},
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"Certificate subjectAltName 'f*o.example.com' wildcard is only allowed as the entire left-most label",
+				"Certificate subjectAltName '*foo.example.com' wildcard is only allowed as the entire left-most label",
+				"Certificate subjectAltName 'www.*.example.com' wildcard is only allowed as the entire left-most label",
+			},
+		},
+		{
+			Name:     "Invalid: more than one wildcard",
+			DNSNames: []string{"*.*.example.com"},
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '*.*.example.com' contains more than one wildcard",
+			},
+		},
+		{
+			Name:     "Invalid: wildcard above a public suffix",
+			DNSNames: []string{"*.com", "*.co.uk", "*.xn--p1ai", "*.blogspot.com", "*"},
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '*.com' wildcard is directly above the public suffix \"com\"",
+				"Certificate subjectAltName '*.co.uk' wildcard is directly above the public suffix \"co.uk\"",
+				"Certificate subjectAltName '*.xn--p1ai' wildcard is directly above the public suffix \"xn--p1ai\"",
+				"Certificate subjectAltName '*.blogspot.com' wildcard is directly above the public suffix \"blogspot.com\"",
+				"Certificate subjectAltName '*' wildcard is not followed by a domain name",
+			},
+		},
+		{
+			Name:           "Invalid: wildcard in IP address and email address",
+			CommonName:     "192.168.1.*",
+			EmailAddresses: []string{"*@example.com"},
+			CertType:       "DV",
+			ExpectedErrors: []string{
+				"Certificate common name '192.168.1.*' contains a wildcard in an IP address",
+				"Certificate subjectAltName '*@example.com' contains a wildcard in an email address",
+			},
+		},
+		{
+			Name:       "Invalid: wildcard in EV",
+			CommonName: "*.example.com",
+			DNSNames:   []string{"*.example.com"},
+			CertType:   "EV",
+			ExpectedErrors: []string{
+				"Certificate should not contain a wildcard",
+				"Certificate subjectAltName '*.example.com' should not contain a wildcard",
+			},
+		},
+		{
+			Name:      "Valid: onion wildcard in EV",
+			DNSNames:  []string{"*.duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"},
+			CertType:  "EV",
+			NotBefore: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:      "Invalid: onion wildcard in EV before Ballot 144",
+			DNSNames:  []string{"*.facebookcorewwwi.onion"},
+			CertType:  "EV",
+			NotBefore: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedErrors: []string{
+				"Certificate subjectAltName '*.facebookcorewwwi.onion' should not contain a wildcard",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{
+					Subject:        pkix.Name{CommonName: tc.CommonName},
+					DNSNames:       tc.DNSNames,
+					EmailAddresses: tc.EmailAddresses,
+					NotBefore:      tc.NotBefore,
+				},
+				Type: tc.CertType,
+			}
+
+			errList := Check(d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestPrivateSuffixes(t *testing.T) {
+	d := &certdata.Data{
+		Cert: &x509.Certificate{DNSNames: []string{"*.blogspot.com"}},
+		Type: "DV",
+	}
+
+	SetPrivateSuffixes(false)
+	defer SetPrivateSuffixes(true)
+	if errList := Check(d).List(); len(errList) != 0 {
+		t.Errorf("expected no errors, got %v", errList)
+	}
+}
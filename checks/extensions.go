@@ -40,10 +40,10 @@ func (ex extensions) Check(ext pkix.Extension, d *certdata.Data) *errors.Errors
 	for _, ec := range ex {
 		if ec.oid.Equal(ext.Id) {
 			found = true
-			if ec.filter != nil && ec.filter.Check(d) {
+			if ec.filter != nil && !ec.filter.Check(d) {
 				continue
 			}
-			e.Append(ec.f(ext, d))
+			e.Append(Run(ec.name, d, func() *errors.Errors { return ec.f(ext, d) }))
 		}
 	}
 
@@ -6,6 +6,7 @@ import (
 	_ "github.com/globalsign/certlint/checks/extensions/authorityinfoaccess"
 	_ "github.com/globalsign/certlint/checks/extensions/authoritykeyid"
 	_ "github.com/globalsign/certlint/checks/extensions/basicconstraints"
+	_ "github.com/globalsign/certlint/checks/extensions/cabforganizationidentifier"
 	_ "github.com/globalsign/certlint/checks/extensions/crldistributionpoints"
 	_ "github.com/globalsign/certlint/checks/extensions/ct"
 	_ "github.com/globalsign/certlint/checks/extensions/extkeyusage"
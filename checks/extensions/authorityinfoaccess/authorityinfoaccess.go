@@ -3,6 +3,7 @@ package authorityinfoaccess
 import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"net/url"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -13,18 +14,108 @@ const checkName = "AuthorityInfoAccess Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 1}
 
+var (
+	idAdOCSP      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1}
+	idAdCAIssuers = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 2}
+)
+
+// accessDescription as defined in RFC 5280 4.2.2.1
+type accessDescription struct {
+	Method   asn1.ObjectIdentifier
+	Location asn1.RawValue
+}
+
+// The Baseline Requirements only apply to TLS certificates, other certificates
+// may use LDAP locations.
+var brFilter = &checks.Filter{
+	Type: []string{"DV", "OV", "IV", "EV"},
+}
+
 func init() {
 	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
-// TODO: Add more checks https://golang.org/src/github.com/globalsign/certlint/certdata/x509.go?s=15439:18344#L1157
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.2.1
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// RFC: Conforming CAs MUST mark this extension as non-critical.
 	if ex.Critical {
 		e.Err("AuthorityInfoAccess extension set critical")
 	}
 
+	var ads []accessDescription
+	if rest, err := asn1.Unmarshal(ex.Value, &ads); err != nil {
+		e.Err("AuthorityInfoAccess extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("AuthorityInfoAccess extension contains trailing data")
+	}
+
+	// AuthorityInfoAccessSyntax ::= SEQUENCE SIZE (1..MAX) OF AccessDescription
+	if len(ads) == 0 {
+		e.Err("AuthorityInfoAccess extension contains an empty sequence (RFC 5280 4.2.2.1)")
+		return e
+	}
+
+	br := brFilter.Check(d)
+	for _, ad := range ads {
+		var method string
+		switch {
+		case ad.Method.Equal(idAdOCSP):
+			method = "OCSP"
+		case ad.Method.Equal(idAdCAIssuers):
+			method = "caIssuers"
+		default:
+			e.Warning("AuthorityInfoAccess contains an unknown accessMethod %s (RFC 5280 4.2.2.1)", ad.Method.String())
+			continue
+		}
+
+		if ad.Location.Class != asn1.ClassContextSpecific || ad.Location.Tag != certdata.GeneralNameURI {
+			if br {
+				e.Err("AuthorityInfoAccess %s accessLocation is a %s instead of an uniformResourceIdentifier (BR 7.1.2.3 (c))", method, certdata.GeneralNameType(ad.Location.Tag))
+			}
+			continue
+		}
+
+		checkURI(e, method, string(ad.Location.Bytes), br)
+	}
+
 	return e
 }
+
+// checkURI verifies that an accessLocation contains a HTTP URL, the Baseline
+// Requirements do not allow other schemes.
+func checkURI(e *errors.Errors, method, uri string, br bool) {
+	l, err := url.Parse(uri)
+	if err != nil || !l.IsAbs() || len(l.Host) == 0 {
+		e.Err("AuthorityInfoAccess contains an invalid %s URI (%s)", method, uri)
+		return
+	}
+
+	switch l.Scheme {
+	case "http":
+	case "https":
+		// RFC 5019 and RFC 5280 do not define HTTPS, it would cause a loop when
+		// validating the certificate of the server. A caIssuers location is
+		// not needed to validate the server certificate.
+		if method == "OCSP" {
+			e.Err("AuthorityInfoAccess contains a %s URI with an non-preferred scheme (https) (RFC 5280 4.2.2.1)", method)
+		} else {
+			e.Warning("AuthorityInfoAccess contains a %s URI with an non-preferred scheme (https) (RFC 5280 4.2.2.1)", method)
+		}
+	case "ldap", "ldaps":
+		if br {
+			e.Err("AuthorityInfoAccess contains a %s URI with an LDAP scheme (BR 7.1.2.3 (c))", method)
+		}
+	default:
+		if br {
+			e.Err("AuthorityInfoAccess contains a %s URI with an non-preferred scheme (%s) (BR 7.1.2.3 (c))", method, l.Scheme)
+		} else {
+			e.Err("AuthorityInfoAccess contains a %s URI with an non-preferred scheme (%s) (RFC 5280 4.2.2.1)", method, l.Scheme)
+		}
+	}
+}
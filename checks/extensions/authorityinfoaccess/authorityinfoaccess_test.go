@@ -0,0 +1,112 @@
+package authorityinfoaccess
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func generalName(tag int, v string) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, Bytes: []byte(v)}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		Descriptions   []accessDescription
+		Critical       bool
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: OCSP and caIssuers",
+			Descriptions: []accessDescription{
+				{Method: idAdOCSP, Location: generalName(certdata.GeneralNameURI, "http://ocsp.example.com")},
+				{Method: idAdCAIssuers, Location: generalName(certdata.GeneralNameURI, "http://example.com/ca.crt")},
+			},
+		},
+		{
+			Name:         "Invalid: empty sequence",
+			Descriptions: []accessDescription{},
+			ExpectedErrors: []string{
+				"AuthorityInfoAccess extension contains an empty sequence (RFC 5280 4.2.2.1)",
+			},
+		},
+		{
+			Name: "Invalid: critical extension",
+			Descriptions: []accessDescription{
+				{Method: idAdOCSP, Location: generalName(certdata.GeneralNameURI, "http://ocsp.example.com")},
+			},
+			Critical: true,
+			ExpectedErrors: []string{
+				"AuthorityInfoAccess extension set critical",
+			},
+		},
+		{
+			Name: "Invalid: LDAP and HTTPS URI",
+			Type: "DV",
+			Descriptions: []accessDescription{
+				{Method: idAdCAIssuers, Location: generalName(certdata.GeneralNameURI, "ldap://ldap.example.com/cn=ca")},
+				{Method: idAdOCSP, Location: generalName(certdata.GeneralNameURI, "https://ocsp.example.com")},
+			},
+			ExpectedErrors: []string{
+				"AuthorityInfoAccess contains a caIssuers URI with an LDAP scheme (BR 7.1.2.3 (c))",
+				"AuthorityInfoAccess contains a OCSP URI with an non-preferred scheme (https) (RFC 5280 4.2.2.1)",
+			},
+		},
+		{
+			Name: "Valid: LDAP URI and directoryName of a code signing certificate",
+			Type: "CS",
+			Descriptions: []accessDescription{
+				{Method: idAdCAIssuers, Location: generalName(certdata.GeneralNameURI, "ldap://ldap.example.com/cn=ca")},
+				{Method: idAdCAIssuers, Location: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: []byte{0x30, 0x00}}},
+			},
+		},
+		{
+			Name: "Invalid: dNSName instead of URI",
+			Type: "DV",
+			Descriptions: []accessDescription{
+				{Method: idAdOCSP, Location: generalName(certdata.GeneralNameDNS, "ocsp.example.com")},
+			},
+			ExpectedErrors: []string{
+				"AuthorityInfoAccess OCSP accessLocation is a dNSName instead of an uniformResourceIdentifier (BR 7.1.2.3 (c))",
+			},
+		},
+		{
+			Name: "Invalid: relative URI and unknown accessMethod",
+			Descriptions: []accessDescription{
+				{Method: idAdOCSP, Location: generalName(certdata.GeneralNameURI, "/ocsp")},
+				{Method: asn1.ObjectIdentifier{1, 2, 3}, Location: generalName(certdata.GeneralNameURI, "http://example.com")},
+			},
+			ExpectedErrors: []string{
+				"AuthorityInfoAccess contains an invalid OCSP URI (/ocsp)",
+				"AuthorityInfoAccess contains an unknown accessMethod 1.2.3 (RFC 5280 4.2.2.1)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := asn1.Marshal(tc.Descriptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := &certdata.Data{Cert: &x509.Certificate{}, Type: tc.Type}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Critical: tc.Critical, Value: b}, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package authoritykeyid
 
 import (
+	"bytes"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 
@@ -13,17 +14,72 @@ const checkName = "AuthorityKeyId Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 35}
 
+// authorityKeyIdentifier as defined in RFC 5280 4.2.1.1
+type authorityKeyIdentifier struct {
+	KeyIdentifier             []byte        `asn1:"optional,tag:0"`
+	AuthorityCertIssuer       asn1.RawValue `asn1:"optional,tag:1"`
+	AuthorityCertSerialNumber asn1.RawValue `asn1:"optional,tag:2"`
+}
+
 func init() {
 	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
+//
+// Section 7.1.2.11.1 of the Baseline Requirements states:
+//
+//  keyIdentifier MUST be present. MUST be identical to the subjectKeyIdentifier
+//  field of the Issuing CA. authorityCertIssuer and authorityCertSerialNumber
+//  MUST NOT be present.
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.1.1
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// RFC: Conforming CAs MUST mark this extension as non-critical.
 	if ex.Critical {
 		e.Err("AuthorityKeyId extension set critical")
 	}
 
+	var aki authorityKeyIdentifier
+	if rest, err := asn1.Unmarshal(ex.Value, &aki); err != nil {
+		e.Err("AuthorityKeyId extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("AuthorityKeyId extension contains trailing data")
+	}
+
+	if len(aki.KeyIdentifier) == 0 {
+		e.Err("AuthorityKeyId extension contains no keyIdentifier (RFC 5280 4.2.1.1)")
+	}
+
+	hasIssuer := len(aki.AuthorityCertIssuer.FullBytes) > 0
+	hasSerial := len(aki.AuthorityCertSerialNumber.FullBytes) > 0
+	switch d.Type {
+	case "DV", "OV", "IV", "EV":
+		if hasIssuer || hasSerial {
+			e.Err("AuthorityKeyId extension MUST NOT contain authorityCertIssuer or authorityCertSerialNumber (BR 7.1.2.11.1)")
+		}
+	}
+	if hasIssuer != hasSerial {
+		e.Err("AuthorityKeyId extension MUST contain both authorityCertIssuer and authorityCertSerialNumber or neither (RFC 5280 4.2.1.1)")
+	}
+
+	if len(aki.KeyIdentifier) == 0 {
+		return e
+	}
+
+	// The keyIdentifier must match the subjectKeyIdentifier of the issuer, for
+	// self signed certificates that is the certificate itself.
+	issuer := d.Issuer
+	if issuer == nil && d.SelfSigned() {
+		issuer = d.Cert
+	}
+	if issuer != nil && len(issuer.SubjectKeyId) > 0 && !bytes.Equal(aki.KeyIdentifier, issuer.SubjectKeyId) {
+		e.Err("AuthorityKeyId keyIdentifier does not match the SubjectKeyId of the issuer (BR 7.1.2.11.1)")
+	}
+
 	return e
 }
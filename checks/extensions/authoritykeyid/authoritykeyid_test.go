@@ -0,0 +1,122 @@
+package authoritykeyid
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	issuer := &x509.Certificate{SubjectKeyId: []byte{1, 2, 3, 4}}
+
+	extension := func(aki authorityKeyIdentifier, critical bool) pkix.Extension {
+		b, err := asn1.Marshal(aki)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkix.Extension{Id: extensionOid, Critical: critical, Value: b}
+	}
+	certIssuer := asn1.RawValue{FullBytes: []byte{0xa1, 0x00}}
+	certSerial := asn1.RawValue{FullBytes: []byte{0x82, 0x01, 0x01}}
+
+	testCases := []struct {
+		Name           string
+		Type           string
+		InputEx        pkix.Extension
+		Issuer         *x509.Certificate
+		ExpectedErrors []string
+	}{
+		{
+			Name:    "Valid: keyIdentifier matches the issuer",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}}, false),
+			Issuer:  issuer,
+		},
+		{
+			Name:    "Valid: unknown issuer",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{5, 6, 7, 8}}, false),
+		},
+		{
+			Name:    "Invalid: keyIdentifier does not match the issuer",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{5, 6, 7, 8}}, false),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId keyIdentifier does not match the SubjectKeyId of the issuer (BR 7.1.2.11.1)",
+			},
+		},
+		{
+			Name:    "Invalid: critical extension",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}}, true),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId extension set critical",
+			},
+		},
+		{
+			Name:    "Invalid: no keyIdentifier",
+			InputEx: extension(authorityKeyIdentifier{}, false),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId extension contains no keyIdentifier (RFC 5280 4.2.1.1)",
+			},
+		},
+		{
+			Name:    "Invalid: authorityCertIssuer and authorityCertSerialNumber",
+			Type:    "DV",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}, AuthorityCertIssuer: certIssuer, AuthorityCertSerialNumber: certSerial}, false),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId extension MUST NOT contain authorityCertIssuer or authorityCertSerialNumber (BR 7.1.2.11.1)",
+			},
+		},
+		{
+			Name:    "Valid: authorityCertIssuer and authorityCertSerialNumber outside TLS",
+			Type:    "PS",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}, AuthorityCertIssuer: certIssuer, AuthorityCertSerialNumber: certSerial}, false),
+			Issuer:  issuer,
+		},
+		{
+			Name:    "Invalid: authorityCertIssuer without authorityCertSerialNumber",
+			Type:    "DV",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}, AuthorityCertIssuer: certIssuer}, false),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId extension MUST NOT contain authorityCertIssuer or authorityCertSerialNumber (BR 7.1.2.11.1)",
+				"AuthorityKeyId extension MUST contain both authorityCertIssuer and authorityCertSerialNumber or neither (RFC 5280 4.2.1.1)",
+			},
+		},
+		{
+			Name:    "Invalid: authorityCertIssuer without authorityCertSerialNumber outside TLS",
+			Type:    "CS",
+			InputEx: extension(authorityKeyIdentifier{KeyIdentifier: []byte{1, 2, 3, 4}, AuthorityCertIssuer: certIssuer}, false),
+			Issuer:  issuer,
+			ExpectedErrors: []string{
+				"AuthorityKeyId extension MUST contain both authorityCertIssuer and authorityCertSerialNumber or neither (RFC 5280 4.2.1.1)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert:   &x509.Certificate{},
+				Issuer: tc.Issuer,
+				Type:   tc.Type,
+			}
+
+			errList := Check(tc.InputEx, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
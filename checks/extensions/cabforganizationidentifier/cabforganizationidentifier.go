@@ -0,0 +1,93 @@
+package cabforganizationidentifier
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/errors"
+)
+
+const checkName = "CABFOrganizationIdentifier Extension Check"
+
+var extensionOid = asn1.ObjectIdentifier{2, 23, 140, 3, 1}
+
+var organizationIdentifier = asn1.ObjectIdentifier{2, 5, 4, 97}
+
+// cabfOrganizationIdentifier as defined in EV Guidelines 9.8.2
+type cabfOrganizationIdentifier struct {
+	RegistrationSchemeIdentifier string `asn1:"printable"`
+	RegistrationCountry          string `asn1:"printable"`
+	RegistrationStateOrProvince  string `asn1:"optional,tag:0,printable"`
+	RegistrationReference        asn1.RawValue
+}
+
+func init() {
+	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+//
+// Section 9.8.2 of the EV Guidelines states:
+//
+//  If the subject:organizationIdentifier is present, this field MUST be
+//  present. If present, this extension MUST contain a Registration Reference
+//  for a Legal Entity assigned in accordance to the identified Registration
+//  Scheme.
+//
+func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	var oi cabfOrganizationIdentifier
+	if rest, err := asn1.Unmarshal(ex.Value, &oi); err != nil {
+		e.Err("CABFOrganizationIdentifier extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("CABFOrganizationIdentifier extension contains trailing data")
+	}
+
+	if len(oi.RegistrationSchemeIdentifier) != 3 {
+		e.Err("CABFOrganizationIdentifier registrationSchemeIdentifier MUST contain 3 characters")
+	}
+	if len(oi.RegistrationCountry) != 2 {
+		e.Err("CABFOrganizationIdentifier registrationCountry MUST contain 2 characters")
+	}
+	if oi.RegistrationReference.Class != asn1.ClassUniversal || oi.RegistrationReference.Tag != asn1.TagUTF8String {
+		e.Err("CABFOrganizationIdentifier registrationReference is not an UTF8String")
+		return e
+	}
+	if !utf8.Valid(oi.RegistrationReference.Bytes) {
+		e.Err("CABFOrganizationIdentifier registrationReference contains invalid UTF8")
+		return e
+	}
+
+	// The extension contains the same information as the subject
+	// organizationIdentifier
+	var subject string
+	for _, n := range d.Cert.Subject.Names {
+		if n.Type.Equal(organizationIdentifier) {
+			subject, _ = n.Value.(string)
+			break
+		}
+	}
+	if len(subject) == 0 {
+		e.Err("CABFOrganizationIdentifier extension present without subject organizationIdentifier")
+		return e
+	}
+	if s := oi.String(); s != subject {
+		e.Err("CABFOrganizationIdentifier '%s' does not match the subject organizationIdentifier '%s'", s, subject)
+	}
+
+	return e
+}
+
+// String returns the extension in the format of the organizationIdentifier
+func (oi cabfOrganizationIdentifier) String() string {
+	if len(oi.RegistrationStateOrProvince) > 0 {
+		return fmt.Sprintf("%s%s+%s-%s", oi.RegistrationSchemeIdentifier, oi.RegistrationCountry, oi.RegistrationStateOrProvince, oi.RegistrationReference.Bytes)
+	}
+	return fmt.Sprintf("%s%s-%s", oi.RegistrationSchemeIdentifier, oi.RegistrationCountry, oi.RegistrationReference.Bytes)
+}
@@ -0,0 +1,90 @@
+package cabforganizationidentifier
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Extension      cabfOrganizationIdentifier
+		Subject        string
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: matching subject",
+			Extension: cabfOrganizationIdentifier{
+				RegistrationSchemeIdentifier: "NTR",
+				RegistrationCountry:          "US",
+				RegistrationStateOrProvince:  "DE",
+				RegistrationReference:        asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte("1234567")},
+			},
+			Subject: "NTRUS+DE-1234567",
+		},
+		{
+			Name: "Invalid: not matching subject",
+			Extension: cabfOrganizationIdentifier{
+				RegistrationSchemeIdentifier: "NTR",
+				RegistrationCountry:          "US",
+				RegistrationReference:        asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte("1234567")},
+			},
+			Subject: "NTRUS+DE-1234567",
+			ExpectedErrors: []string{
+				"CABFOrganizationIdentifier 'NTRUS-1234567' does not match the subject organizationIdentifier 'NTRUS+DE-1234567'",
+			},
+		},
+		{
+			Name: "Invalid: registrationReference string type",
+			Extension: cabfOrganizationIdentifier{
+				RegistrationSchemeIdentifier: "VAT",
+				RegistrationCountry:          "NL",
+				RegistrationReference:        asn1.RawValue{Tag: asn1.TagPrintableString, Bytes: []byte("123456789")},
+			},
+			ExpectedErrors: []string{
+				"CABFOrganizationIdentifier registrationReference is not an UTF8String",
+			},
+		},
+		{
+			Name: "Invalid: no subject organizationIdentifier",
+			Extension: cabfOrganizationIdentifier{
+				RegistrationSchemeIdentifier: "VAT",
+				RegistrationCountry:          "NL",
+				RegistrationReference:        asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte("123456789")},
+			},
+			ExpectedErrors: []string{
+				"CABFOrganizationIdentifier extension present without subject organizationIdentifier",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := asn1.Marshal(tc.Extension)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cert := &x509.Certificate{}
+			if len(tc.Subject) > 0 {
+				cert.Subject.Names = []pkix.AttributeTypeAndValue{{Type: organizationIdentifier, Value: tc.Subject}}
+			}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Value: b}, &certdata.Data{Cert: cert, Type: "EV"}).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package crldistributionpoints
 import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"net/url"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -13,11 +14,32 @@ const checkName = "CRLDistributionPoints Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 31}
 
+// distributionPoint as defined in RFC 5280 4.2.1.13
+type distributionPoint struct {
+	DistributionPoint asn1.RawValue  `asn1:"optional,tag:0"`
+	Reasons           asn1.BitString `asn1:"optional,tag:1"`
+	CRLIssuer         asn1.RawValue  `asn1:"optional,tag:2"`
+}
+
+// The Baseline Requirements only apply to TLS certificates, other certificates
+// may use LDAP and indirect CRLs.
+var brFilter = &checks.Filter{
+	Type: []string{"DV", "OV", "IV", "EV"},
+}
+
 func init() {
 	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
+//
+// Section 7.1.2.3 (b) of the Baseline Requirements states:
+//
+//  If present, this extension MUST NOT be marked critical, and it MUST
+//  contain the HTTP URL of the CA's CRL service.
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.1.13
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
@@ -25,5 +47,122 @@ func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 		e.Err("CRLDistributionPoints extension set critical")
 	}
 
+	var dps []distributionPoint
+	if rest, err := asn1.Unmarshal(ex.Value, &dps); err != nil {
+		e.Err("CRLDistributionPoints extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("CRLDistributionPoints extension contains trailing data")
+	}
+
+	// CRLDistributionPoints ::= SEQUENCE SIZE (1..MAX) OF DistributionPoint
+	if len(dps) == 0 {
+		e.Err("CRLDistributionPoints extension contains an empty sequence (RFC 5280 4.2.1.13)")
+		return e
+	}
+
+	br := brFilter.Check(d)
+	for _, dp := range dps {
+		// RFC: Conforming CAs SHOULD NOT use reasonFlags.
+		if dp.Reasons.BitLength > 0 {
+			e.Err("CRLDistributionPoints contains a DistributionPoint with reasons set (RFC 5280 4.2.1.13)")
+		}
+
+		// The CRL MUST be issued by the certificate issuer, indirect CRLs are
+		// not allowed by the Baseline Requirements.
+		if br && len(dp.CRLIssuer.FullBytes) > 0 {
+			e.Err("CRLDistributionPoints contains a DistributionPoint with cRLIssuer set (BR 7.1.2.3 (b))")
+		}
+
+		if len(dp.DistributionPoint.FullBytes) == 0 {
+			if br {
+				e.Err("CRLDistributionPoints contains a DistributionPoint without distributionPoint (BR 7.1.2.3 (b))")
+			}
+			continue
+		}
+
+		checkDistributionPointName(e, dp.DistributionPoint, br)
+	}
+
 	return e
 }
+
+// checkDistributionPointName verifies the DistributionPointName choice, the
+// Baseline Requirements only accept a fullName containing URIs.
+//
+//  DistributionPointName ::= CHOICE {
+//       fullName                [0]     GeneralNames,
+//       nameRelativeToCRLIssuer [1]     RelativeDistinguishedName }
+//
+func checkDistributionPointName(e *errors.Errors, dpn asn1.RawValue, br bool) {
+	var name asn1.RawValue
+	if _, err := asn1.Unmarshal(dpn.Bytes, &name); err != nil {
+		e.Err("CRLDistributionPoints distributionPoint can't be decoded: %s", err.Error())
+		return
+	}
+
+	if name.Class != asn1.ClassContextSpecific {
+		e.Err("CRLDistributionPoints distributionPoint contains an invalid DistributionPointName")
+		return
+	}
+
+	switch name.Tag {
+	case 0:
+	case 1:
+		if br {
+			e.Err("CRLDistributionPoints distributionPoint contains a nameRelativeToCRLIssuer (BR 7.1.2.3 (b))")
+		}
+		return
+	default:
+		e.Err("CRLDistributionPoints distributionPoint contains an invalid DistributionPointName")
+		return
+	}
+
+	gns, err := certdata.ParseGeneralNames(name.Bytes)
+	if err != nil {
+		e.Err("CRLDistributionPoints fullName can't be decoded: %s", err.Error())
+		return
+	}
+	if len(gns) == 0 {
+		e.Err("CRLDistributionPoints fullName contains an empty sequence (RFC 5280 4.2.1.13)")
+		return
+	}
+
+	for _, gn := range gns {
+		if gn.Tag != certdata.GeneralNameURI {
+			if br {
+				e.Err("CRLDistributionPoints fullName contains a %s instead of an uniformResourceIdentifier (BR 7.1.2.3 (b))", certdata.GeneralNameType(gn.Tag))
+			}
+			continue
+		}
+		checkURI(e, string(gn.Bytes), br)
+	}
+}
+
+// checkURI verifies that the CRL location is a HTTP URL, LDAP is only allowed
+// outside the Baseline Requirements.
+func checkURI(e *errors.Errors, uri string, br bool) {
+	l, err := url.Parse(uri)
+	if err != nil || !l.IsAbs() || len(l.Host) == 0 {
+		e.Err("CRLDistributionPoints contains an invalid CRL URI (%s)", uri)
+		return
+	}
+
+	switch l.Scheme {
+	case "http":
+	case "https":
+		// HTTPS is not defined by RFC 5280 and may result in a loop when
+		// validating the certificate of the server.
+		e.Err("CRLDistributionPoints contains a CRL URI with an non-preferred scheme (https) (RFC 5280 4.2.1.13)")
+	case "ldap", "ldaps":
+		if br {
+			e.Err("CRLDistributionPoints contains a CRL URI with an LDAP scheme (BR 7.1.2.3 (b))")
+		}
+	default:
+		if br {
+			e.Err("CRLDistributionPoints contains a CRL URI with an non-preferred scheme (%s) (BR 7.1.2.3 (b))", l.Scheme)
+		} else {
+			e.Err("CRLDistributionPoints contains a CRL URI with an non-preferred scheme (%s) (RFC 5280 4.2.1.13)", l.Scheme)
+		}
+	}
+}
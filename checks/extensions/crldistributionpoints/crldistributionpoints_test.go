@@ -0,0 +1,116 @@
+package crldistributionpoints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func fullName(t *testing.T, names ...asn1.RawValue) asn1.RawValue {
+	var b []byte
+	for _, n := range names {
+		nb, err := asn1.Marshal(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b = append(b, nb...)
+	}
+	fn, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: fn}
+}
+
+func generalName(tag int, v string) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, Bytes: []byte(v)}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		Points         []distributionPoint
+		ExpectedErrors []string
+	}{
+		{
+			Name:   "Valid: HTTP URI",
+			Points: []distributionPoint{{DistributionPoint: fullName(t, generalName(certdata.GeneralNameURI, "http://crl.example.com/ca.crl"))}},
+		},
+		{
+			Name:   "Invalid: empty sequence",
+			Points: []distributionPoint{},
+			ExpectedErrors: []string{
+				"CRLDistributionPoints extension contains an empty sequence (RFC 5280 4.2.1.13)",
+			},
+		},
+		{
+			Name:   "Invalid: LDAP and HTTPS URI",
+			Type:   "DV",
+			Points: []distributionPoint{{DistributionPoint: fullName(t, generalName(certdata.GeneralNameURI, "ldap://ldap.example.com/cn=ca"), generalName(certdata.GeneralNameURI, "https://crl.example.com/ca.crl"))}},
+			ExpectedErrors: []string{
+				"CRLDistributionPoints contains a CRL URI with an LDAP scheme (BR 7.1.2.3 (b))",
+				"CRLDistributionPoints contains a CRL URI with an non-preferred scheme (https) (RFC 5280 4.2.1.13)",
+			},
+		},
+		{
+			Name:   "Valid: LDAP URI of a S/MIME certificate",
+			Type:   "PS",
+			Points: []distributionPoint{{DistributionPoint: fullName(t, generalName(certdata.GeneralNameURI, "ldap://ldap.example.com/cn=ca"))}},
+		},
+		{
+			Name:   "Invalid: HTTPS URI of a S/MIME certificate",
+			Type:   "PS",
+			Points: []distributionPoint{{DistributionPoint: fullName(t, generalName(certdata.GeneralNameURI, "https://crl.example.com/ca.crl"))}},
+			ExpectedErrors: []string{
+				"CRLDistributionPoints contains a CRL URI with an non-preferred scheme (https) (RFC 5280 4.2.1.13)",
+			},
+		},
+		{
+			Name:   "Invalid: dNSName instead of URI",
+			Type:   "DV",
+			Points: []distributionPoint{{DistributionPoint: fullName(t, generalName(certdata.GeneralNameDNS, "crl.example.com"))}},
+			ExpectedErrors: []string{
+				"CRLDistributionPoints fullName contains a dNSName instead of an uniformResourceIdentifier (BR 7.1.2.3 (b))",
+			},
+		},
+		{
+			Name: "Invalid: reasons and cRLIssuer without distributionPoint",
+			Type: "DV",
+			Points: []distributionPoint{{
+				Reasons:   asn1.BitString{Bytes: []byte{0x40}, BitLength: 2},
+				CRLIssuer: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: []byte{0x86, 0x01, 0x61}},
+			}},
+			ExpectedErrors: []string{
+				"CRLDistributionPoints contains a DistributionPoint with reasons set (RFC 5280 4.2.1.13)",
+				"CRLDistributionPoints contains a DistributionPoint with cRLIssuer set (BR 7.1.2.3 (b))",
+				"CRLDistributionPoints contains a DistributionPoint without distributionPoint (BR 7.1.2.3 (b))",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := asn1.Marshal(tc.Points)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := &certdata.Data{Cert: &x509.Certificate{}, Type: tc.Type}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Value: b}, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
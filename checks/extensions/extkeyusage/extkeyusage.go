@@ -7,6 +7,7 @@ import (
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/keyusage/matrix"
 	"github.com/globalsign/certlint/errors"
 )
 
@@ -43,5 +44,16 @@ func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 		}
 	}
 
+	for _, r := range matrix.Match(d) {
+		if r.ExtKeyUsageCritical == matrix.Required && !ex.Critical {
+			e.Err("ExtKeyUsage extension MUST be marked as critical for %s certificates", d.Type)
+			break
+		}
+		if r.ExtKeyUsageCritical == matrix.Forbidden && ex.Critical {
+			e.Err("ExtKeyUsage extension MUST NOT be marked as critical for %s certificates", d.Type)
+			break
+		}
+	}
+
 	return e
 }
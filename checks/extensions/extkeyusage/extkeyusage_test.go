@@ -0,0 +1,79 @@
+package extkeyusage
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		IsCA           bool
+		ExtKeyUsage    []x509.ExtKeyUsage
+		Critical       bool
+		ExpectedErrors []string
+	}{
+		{
+			Name:        "Valid: DV",
+			Type:        "DV",
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		{
+			Name:        "Invalid: CA",
+			Type:        "CA",
+			IsCA:        true,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			ExpectedErrors: []string{
+				"In general ExtKeyUsage will appear only in end entity certificates",
+			},
+		},
+		{
+			Name:        "Valid: PS critical",
+			Type:        "PS",
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+			Critical:    true,
+		},
+		{
+			Name:        "Invalid: PS critical with anyExtendedKeyUsage",
+			Type:        "PS",
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection, x509.ExtKeyUsageAny},
+			Critical:    true,
+			ExpectedErrors: []string{
+				"ExtKeyUsage extension SHOULD NOT be critical if anyExtendedKeyUsage is present",
+			},
+		},
+		{
+			Name:        "Invalid: TS not critical",
+			Type:        "TS",
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+			ExpectedErrors: []string{
+				"ExtKeyUsage extension MUST be marked as critical for TS certificates",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{IsCA: tc.IsCA, ExtKeyUsage: tc.ExtKeyUsage},
+				Type: tc.Type,
+			}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Critical: tc.Critical}, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/keyusage/matrix"
 	"github.com/globalsign/certlint/errors"
 )
 
@@ -24,8 +25,17 @@ func init() {
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	if !ex.Critical {
-		e.Err("KeyUsage extension SHOULD be marked as critical when present")
+	// The criticality is defined by the key usage matrix, by default the
+	// extension SHOULD be marked as critical when present.
+	for _, r := range matrix.Match(d) {
+		if r.KeyUsageCritical == matrix.Required && !ex.Critical {
+			e.Err("KeyUsage extension SHOULD be marked as critical when present")
+			break
+		}
+		if r.KeyUsageCritical == matrix.Forbidden && ex.Critical {
+			e.Err("KeyUsage extension MUST NOT be marked as critical for %s certificates", d.Type)
+			break
+		}
 	}
 
 	return e
@@ -0,0 +1,73 @@
+package keyusage
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Type           string
+		Critical       bool
+		ExpectedErrors []string
+	}{
+		{
+			Name:     "Valid: DV",
+			Type:     "DV",
+			Critical: true,
+		},
+		{
+			Name: "Invalid: DV not critical",
+			Type: "DV",
+			ExpectedErrors: []string{
+				"KeyUsage extension SHOULD be marked as critical when present",
+			},
+		},
+		{
+			Name:     "Valid: CA",
+			Type:     "CA",
+			Critical: true,
+		},
+		{
+			Name: "Invalid: CA not critical",
+			Type: "CA",
+			ExpectedErrors: []string{
+				"KeyUsage extension SHOULD be marked as critical when present",
+			},
+		},
+		{
+			Name:     "Valid: PS",
+			Type:     "PS",
+			Critical: true,
+		},
+		{
+			Name: "Invalid: PS not critical",
+			Type: "PS",
+			ExpectedErrors: []string{
+				"KeyUsage extension SHOULD be marked as critical when present",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{Cert: &x509.Certificate{}, Type: tc.Type}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Critical: tc.Critical}, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
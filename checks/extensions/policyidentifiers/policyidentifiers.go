@@ -3,6 +3,9 @@ package policyidentifiers
 import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"net/url"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -11,8 +14,43 @@ import (
 
 const checkName = "PolicyIdentifiers Extension Check"
 
+// ub of DisplayText in RFC 5280 4.2.1.4
+const maxNoticeSize = 200
+
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 32}
 
+var (
+	anyPolicy    = asn1.ObjectIdentifier{2, 5, 29, 32, 0}
+	idQtCps      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 1}
+	idQtUnotice  = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 2}
+	cabfReserved = asn1.ObjectIdentifier{2, 23, 140, 1}
+)
+
+// CA/Browser Forum reserved policy identifiers and the certificate type they
+// represent.
+var cabfPolicyType = []struct {
+	oid      asn1.ObjectIdentifier
+	certType string
+}{
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 1}, "EV"},
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1}, "DV"},
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 2, 2}, "OV"},
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 2, 3}, "IV"},
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 3}, "EVCS"},
+	{asn1.ObjectIdentifier{2, 23, 140, 1, 4, 1}, "CS"},
+}
+
+// policyInformation as defined in RFC 5280 4.2.1.4
+type policyInformation struct {
+	Policy     asn1.ObjectIdentifier
+	Qualifiers []policyQualifierInfo `asn1:"optional"`
+}
+
+type policyQualifierInfo struct {
+	PolicyQualifierID asn1.ObjectIdentifier
+	Qualifier         asn1.RawValue
+}
+
 func init() {
 	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
 }
@@ -26,6 +64,9 @@ func init() {
 //  A Policy Identifier, defined by the issuing CA, that indicates a
 //  Certificate Policy asserting the issuing CA's adherence to and compliance
 //  with these Requirements.
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.1.4
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
@@ -34,10 +75,185 @@ func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 		e.Err("PolicyIdentifiers extension set critical")
 	}
 
+	var policies []policyInformation
+	if rest, err := asn1.Unmarshal(ex.Value, &policies); err != nil {
+		e.Err("PolicyIdentifiers extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("PolicyIdentifiers extension contains trailing data")
+	}
+
 	// A policy must be defined
-	if len(d.Cert.PolicyIdentifiers) == 0 {
+	if len(policies) == 0 {
 		e.Err("PolicyIdentifiers not present")
+		return e
 	}
 
+	var reserved []asn1.ObjectIdentifier
+	for i, p := range policies {
+		// RFC: A certificate policy OID MUST NOT appear more than once in a
+		// certificate policies extension.
+		for _, prev := range policies[:i] {
+			if prev.Policy.Equal(p.Policy) {
+				e.Err("PolicyIdentifiers contains duplicate policy %s", p.Policy.String())
+				break
+			}
+		}
+
+		// anyPolicy is only allowed in (subordinate) CA certificates
+		if p.Policy.Equal(anyPolicy) && !d.Cert.IsCA {
+			e.Err("PolicyIdentifiers contains anyPolicy in an end entity certificate")
+		}
+
+		if isReserved(p.Policy) {
+			reserved = append(reserved, p.Policy)
+		}
+
+		for _, q := range p.Qualifiers {
+			switch {
+			case q.PolicyQualifierID.Equal(idQtCps):
+				checkCPSURI(e, q.Qualifier)
+			case q.PolicyQualifierID.Equal(idQtUnotice):
+				checkUserNotice(e, q.Qualifier)
+			default:
+				e.Err("PolicyIdentifiers contains unknown policy qualifier %s", q.PolicyQualifierID.String())
+			}
+		}
+	}
+
+	checkReserved(e, reserved, d.Type)
+
 	return e
 }
+
+// checkCPSURI verifies that the CPS pointer qualifier is an IA5String that
+// contains an absolute http(s) URI.
+func checkCPSURI(e *errors.Errors, q asn1.RawValue) {
+	if q.Class != asn1.ClassUniversal || q.Tag != asn1.TagIA5String {
+		e.Err("PolicyIdentifiers CPS qualifier is not an IA5String")
+		return
+	}
+
+	u, err := url.Parse(string(q.Bytes))
+	if err != nil || !u.IsAbs() || len(u.Host) == 0 {
+		e.Err("PolicyIdentifiers contains an invalid CPS URI (%s)", string(q.Bytes))
+		return
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		e.Err("PolicyIdentifiers CPS URI with an non-preferred scheme (%s)", u.Scheme)
+	}
+}
+
+// checkUserNotice verifies the user notice qualifier.
+//
+// RFC 5280 4.2.1.4 (updated by RFC 6818):
+//
+//  Conforming CAs SHOULD use the UTF8String encoding for explicitText.
+//  VisibleString or BMPString are acceptable but less preferred alternatives.
+//  Conforming CAs MUST NOT encode explicitText as IA5String. The explicitText
+//  string SHOULD NOT include any control characters.
+//
+//  Conforming CAs SHOULD NOT use the noticeRef option.
+func checkUserNotice(e *errors.Errors, q asn1.RawValue) {
+	if q.Class != asn1.ClassUniversal || q.Tag != asn1.TagSequence {
+		e.Err("PolicyIdentifiers user notice qualifier is not a sequence")
+		return
+	}
+
+	var v asn1.RawValue
+	for rest := q.Bytes; len(rest) > 0; {
+		var err error
+		rest, err = asn1.Unmarshal(rest, &v)
+		if err != nil {
+			e.Err("PolicyIdentifiers user notice can't be decoded: %s", err.Error())
+			return
+		}
+
+		if v.Class != asn1.ClassUniversal {
+			e.Err("PolicyIdentifiers user notice contains an unexpected tag")
+			continue
+		}
+
+		switch v.Tag {
+		case asn1.TagSequence:
+			e.Warning("PolicyIdentifiers user notice SHOULD NOT use the noticeRef option")
+		case asn1.TagUTF8String:
+			if !utf8.Valid(v.Bytes) {
+				e.Err("PolicyIdentifiers user notice explicitText contains invalid UTF8")
+			}
+			checkNoticeLength(e, utf8.RuneCount(v.Bytes))
+			checkNoticeControl(e, v.Bytes)
+		case 26: // VisibleString
+			checkNoticeLength(e, len(v.Bytes))
+			checkNoticeControl(e, v.Bytes)
+		case 30: // BMPString
+			e.Warning("PolicyIdentifiers user notice explicitText SHOULD be encoded as UTF8String instead of BMPString")
+			checkNoticeLength(e, len(v.Bytes)/2)
+		case asn1.TagIA5String:
+			e.Err("PolicyIdentifiers user notice explicitText MUST NOT be encoded as IA5String")
+			checkNoticeLength(e, len(v.Bytes))
+		default:
+			e.Err("PolicyIdentifiers user notice explicitText has an invalid encoding")
+		}
+	}
+}
+
+// checkNoticeLength checks the size of DisplayText (SIZE (1..200))
+func checkNoticeLength(e *errors.Errors, l int) {
+	if l == 0 {
+		e.Err("PolicyIdentifiers user notice explicitText is empty")
+	} else if l > maxNoticeSize {
+		e.Err("PolicyIdentifiers user notice explicitText exceeds %d characters", maxNoticeSize)
+	}
+}
+
+// checkNoticeControl reports control characters in the explicitText
+func checkNoticeControl(e *errors.Errors, b []byte) {
+	for _, r := range string(b) {
+		if unicode.IsControl(r) {
+			e.Warning("PolicyIdentifiers user notice explicitText SHOULD NOT include control characters")
+			return
+		}
+	}
+}
+
+// checkReserved verifies that exactly one CA/Browser Forum reserved policy
+// identifier is included for certificate types that require one and that it
+// matches the detected certificate type. The type detection does not
+// distinguish EV code signing certificates, a CS certificate may contain the EV
+// code signing policy.
+func checkReserved(e *errors.Errors, reserved []asn1.ObjectIdentifier, certType string) {
+	switch certType {
+	case "DV", "OV", "IV", "EV", "CS", "EVCS":
+	default:
+		return
+	}
+
+	if len(reserved) == 0 {
+		e.Err("PolicyIdentifiers contains no CA/Browser Forum reserved policy identifier")
+		return
+	}
+	if len(reserved) > 1 {
+		e.Err("PolicyIdentifiers contains more than one CA/Browser Forum reserved policy identifier")
+		return
+	}
+
+	for _, pt := range cabfPolicyType {
+		if pt.oid.Equal(reserved[0]) {
+			if pt.certType != certType && !(pt.certType == "EVCS" && certType == "CS") {
+				e.Err("PolicyIdentifiers contains CA/Browser Forum policy %s for %s certificates, but certificate is detected as %s", reserved[0].String(), pt.certType, certType)
+			}
+			return
+		}
+	}
+	e.Err("PolicyIdentifiers contains unknown CA/Browser Forum reserved policy identifier %s", reserved[0].String())
+}
+
+// isReserved returns true if the policy is within the CA/Browser Forum
+// reserved 2.23.140.1 arc.
+func isReserved(oid asn1.ObjectIdentifier) bool {
+	if len(oid) <= len(cabfReserved) {
+		return false
+	}
+	return cabfReserved.Equal(oid[:len(cabfReserved)])
+}
@@ -0,0 +1,148 @@
+package policyidentifiers
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"strings"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func policyExtension(t *testing.T, policies ...policyInformation) pkix.Extension {
+	b, err := asn1.Marshal(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: extensionOid, Value: b}
+}
+
+func qualifier(t *testing.T, id asn1.ObjectIdentifier, v interface{}, params string) policyQualifierInfo {
+	b, err := asn1.MarshalWithParams(v, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policyQualifierInfo{PolicyQualifierID: id, Qualifier: asn1.RawValue{FullBytes: b}}
+}
+
+func TestCheck(t *testing.T) {
+	ov := asn1.ObjectIdentifier{2, 23, 140, 1, 2, 2}
+	dv := asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1}
+	cp := asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 4146, 1, 20}
+	evcs := asn1.ObjectIdentifier{2, 23, 140, 1, 3}
+	cs := asn1.ObjectIdentifier{2, 23, 140, 1, 4, 1}
+
+	type userNotice struct {
+		ExplicitText string `asn1:"ia5"`
+	}
+
+	testCases := []struct {
+		Name           string
+		Policies       []policyInformation
+		CertType       string
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid: OV policy with CPS",
+			Policies: []policyInformation{
+				{Policy: cp, Qualifiers: []policyQualifierInfo{qualifier(t, idQtCps, "https://www.example.com/repository/", "ia5")}},
+				{Policy: ov},
+			},
+			CertType: "OV",
+		},
+		{
+			Name:     "Invalid: duplicate policy",
+			Policies: []policyInformation{{Policy: ov}, {Policy: ov}},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers contains duplicate policy 2.23.140.1.2.2",
+				"PolicyIdentifiers contains more than one CA/Browser Forum reserved policy identifier",
+			},
+		},
+		{
+			Name:     "Invalid: anyPolicy in end entity",
+			Policies: []policyInformation{{Policy: anyPolicy}, {Policy: dv}},
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers contains anyPolicy in an end entity certificate",
+			},
+		},
+		{
+			Name:     "Invalid: reserved policy mismatch",
+			Policies: []policyInformation{{Policy: dv}},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers contains CA/Browser Forum policy 2.23.140.1.2.1 for DV certificates, but certificate is detected as OV",
+			},
+		},
+		{
+			Name:     "Valid: EV code signing policy in a code signing certificate",
+			Policies: []policyInformation{{Policy: evcs}},
+			CertType: "CS",
+		},
+		{
+			Name:     "Valid: code signing policy",
+			Policies: []policyInformation{{Policy: cs}},
+			CertType: "CS",
+		},
+		{
+			Name:     "Invalid: EV code signing policy in a TLS certificate",
+			Policies: []policyInformation{{Policy: evcs}},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers contains CA/Browser Forum policy 2.23.140.1.3 for EVCS certificates, but certificate is detected as OV",
+			},
+		},
+		{
+			Name:     "Invalid: no reserved policy",
+			Policies: []policyInformation{{Policy: cp}},
+			CertType: "EV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers contains no CA/Browser Forum reserved policy identifier",
+			},
+		},
+		{
+			Name: "Invalid: CPS URI scheme",
+			Policies: []policyInformation{
+				{Policy: ov, Qualifiers: []policyQualifierInfo{qualifier(t, idQtCps, "ldap://ldap.example.com/", "ia5")}},
+			},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers CPS URI with an non-preferred scheme (ldap)",
+			},
+		},
+		{
+			Name: "Invalid: IA5String explicitText exceeding 200 characters",
+			Policies: []policyInformation{
+				{Policy: ov, Qualifiers: []policyQualifierInfo{qualifier(t, idQtUnotice, userNotice{strings.Repeat("a", 201)}, "")}},
+			},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"PolicyIdentifiers user notice explicitText MUST NOT be encoded as IA5String",
+				"PolicyIdentifiers user notice explicitText exceeds 200 characters",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{},
+				Type: tc.CertType,
+			}
+
+			errList := Check(policyExtension(t, tc.Policies...), d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
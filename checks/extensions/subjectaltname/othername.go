@@ -0,0 +1,106 @@
+package subjectaltname
+
+import (
+	"encoding/asn1"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
+)
+
+var (
+	idOnUPN             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+	idOnXMPPAddr        = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 5}
+	idOnDNSSRV          = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 7}
+	idOnSmtpUTF8Mailbox = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 9}
+)
+
+// checkOtherName verifies the value of the known otherName types
+func checkOtherName(e *errors.Errors, gn asn1.RawValue, d *certdata.Data) {
+	on, err := certdata.ParseOtherName(gn)
+	if err != nil {
+		e.Err("SubjectAltName contains an otherName that can't be decoded: %s", err.Error())
+		return
+	}
+
+	switch {
+	case on.TypeID.Equal(idOnUPN):
+		// Microsoft User Principal Name, user@domain
+		if s, ok := utf8Value(e, "UPN", on.Value); ok {
+			if i := strings.LastIndex(s, "@"); i < 1 || i == len(s)-1 {
+				e.Err("SubjectAltName otherName UPN '%s' is not formatted as user@domain", s)
+			}
+		}
+
+	case on.TypeID.Equal(idOnSmtpUTF8Mailbox):
+		// The mailboxes of PS certificates are validated with the other email
+		// addresses by the certificate SubjectAltName check
+		if !mailboxChecked(d) {
+			utf8Value(e, "SmtpUTF8Mailbox", on.Value)
+		}
+
+	case on.TypeID.Equal(idOnXMPPAddr):
+		// RFC 6120 13.7.1.4: XmppAddr ::= UTF8String, containing a JID
+		if s, ok := utf8Value(e, "XmppAddr", on.Value); ok {
+			if !validJID(s) {
+				e.Err("SubjectAltName otherName XmppAddr '%s' is not a valid JID (RFC 6120 13.7.1.4)", s)
+			}
+		}
+
+	case on.TypeID.Equal(idOnDNSSRV):
+		// RFC 4985 2: SRVName ::= IA5String (SIZE (1..MAX)), _Service.Name
+		if on.Value.Class != asn1.ClassUniversal || on.Value.Tag != asn1.TagIA5String {
+			e.Err("SubjectAltName otherName SRVName is not an IA5String (RFC 4985 2)")
+			return
+		}
+		s := string(on.Value.Bytes)
+		if i := strings.Index(s, "."); !strings.HasPrefix(s, "_") || i < 2 || i == len(s)-1 {
+			e.Err("SubjectAltName otherName SRVName '%s' is not formatted as _Service.Name (RFC 4985 2)", s)
+		}
+
+	default:
+		e.Notice("SubjectAltName contains an unknown otherName %s", on.TypeID.String())
+	}
+}
+
+// utf8Value returns the value of an otherName that must be an UTF8String
+func utf8Value(e *errors.Errors, name string, v asn1.RawValue) (string, bool) {
+	if v.Class != asn1.ClassUniversal || v.Tag != asn1.TagUTF8String {
+		e.Err("SubjectAltName otherName %s is not an UTF8String", name)
+		return "", false
+	}
+	if !utf8.Valid(v.Bytes) {
+		e.Err("SubjectAltName otherName %s contains invalid UTF8", name)
+		return "", false
+	}
+	if len(v.Bytes) == 0 {
+		e.Err("SubjectAltName otherName %s is empty", name)
+		return "", false
+	}
+	return string(v.Bytes), true
+}
+
+// validJID verifies the basic structure of a JID, [localpart@]domainpart
+// followed by an optional /resourcepart (RFC 7622 3.1)
+func validJID(s string) bool {
+	if i := strings.Index(s, "/"); i > -1 {
+		if i == len(s)-1 {
+			return false
+		}
+		s = s[:i]
+	}
+	if i := strings.Index(s, "@"); i > -1 {
+		if i == 0 {
+			return false
+		}
+		s = s[i+1:]
+	}
+	return len(s) > 0 && !strings.ContainsAny(s, "@ ")
+}
+
+// mailboxChecked returns true if the rfc822Name and SmtpUTF8Mailbox names are
+// validated by the certificate SubjectAltName check
+func mailboxChecked(d *certdata.Data) bool {
+	return d.Type == "PS"
+}
@@ -1,8 +1,10 @@
 package subjectaltname
 
 import (
+	"bytes"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"strings"
 
 	"github.com/globalsign/certlint/certdata"
 	"github.com/globalsign/certlint/checks"
@@ -13,17 +15,152 @@ const checkName = "SubjectAltName Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 17}
 
+// allowedTypes lists the GeneralName choices that are expected per certificate
+// type, types that are not listed are not restricted.
+var allowedTypes = map[string][]int{
+	// BR 7.1.2.7.12: only dNSName and iPAddress are allowed
+	"DV": {certdata.GeneralNameDNS, certdata.GeneralNameIPAddress},
+	"OV": {certdata.GeneralNameDNS, certdata.GeneralNameIPAddress},
+	"IV": {certdata.GeneralNameDNS, certdata.GeneralNameIPAddress},
+	"EV": {certdata.GeneralNameDNS, certdata.GeneralNameIPAddress},
+	// S/MIME BR 7.1.2.3 (h): mailbox addresses and otherNames
+	"PS": {certdata.GeneralNameOther, certdata.GeneralNameRFC822, certdata.GeneralNameDirectory},
+}
+
 func init() {
 	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.1.6
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	if ex.Critical {
+	// RFC: If the subject field contains an empty sequence, then the issuing CA
+	// MUST include a subjectAltName extension that is marked as critical.
+	if emptySubject(d) {
+		if !ex.Critical {
+			e.Err("SubjectAltName extension MUST be critical when the subject is empty (RFC 5280 4.2.1.6)")
+		}
+	} else if ex.Critical {
 		e.Err("SubjectAltName extension set critical")
 	}
 
+	var seq asn1.RawValue
+	if rest, err := asn1.Unmarshal(ex.Value, &seq); err != nil {
+		e.Err("SubjectAltName extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("SubjectAltName extension contains trailing data")
+	}
+	if seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence {
+		e.Err("SubjectAltName extension is not a sequence")
+		return e
+	}
+
+	gns, err := certdata.ParseGeneralNames(seq.Bytes)
+	if err != nil {
+		e.Err("SubjectAltName extension can't be decoded: %s", err.Error())
+		return e
+	}
+
+	// GeneralNames ::= SEQUENCE SIZE (1..MAX) OF GeneralName
+	if len(gns) == 0 {
+		e.Err("SubjectAltName extension contains an empty sequence (RFC 5280 4.2.1.6)")
+		return e
+	}
+
+	allowed, restricted := allowedTypes[d.Type]
+	for i, gn := range gns {
+		name := certdata.GeneralNameType(gn.Tag)
+
+		if restricted && !containsType(allowed, gn.Tag) {
+			// Client certificates are detected as PS as well, be less strict
+			if d.Type == "PS" {
+				e.Warning("SubjectAltName contains a %s, which is not expected in %s certificates", name, d.Type)
+			} else {
+				e.Err("SubjectAltName contains a %s, which is not allowed in %s certificates", name, d.Type)
+			}
+		}
+
+		if len(gn.Bytes) == 0 {
+			e.Err("SubjectAltName contains an empty %s", name)
+			continue
+		}
+
+		for _, prev := range gns[:i] {
+			if equalGeneralName(prev, gn) {
+				e.Warning("SubjectAltName contains a duplicate %s", name)
+				break
+			}
+		}
+
+		switch gn.Tag {
+		case certdata.GeneralNameOther:
+			checkOtherName(e, gn, d)
+		case certdata.GeneralNameRFC822, certdata.GeneralNameDNS, certdata.GeneralNameURI:
+			if gn.Tag == certdata.GeneralNameRFC822 && mailboxChecked(d) {
+				break
+			}
+			// IA5String
+			for _, c := range gn.Bytes {
+				if c >= 0x80 {
+					e.Err("SubjectAltName %s contains non-ASCII characters", name)
+					break
+				}
+			}
+		case certdata.GeneralNameIPAddress:
+			if len(gn.Bytes) != 4 && len(gn.Bytes) != 16 {
+				e.Err("SubjectAltName contains an iPAddress with an invalid length (%d)", len(gn.Bytes))
+			}
+		case certdata.GeneralNameDirectory:
+			// Name is a CHOICE, the explicit tag contains the RDNSequence
+			var rdn pkix.RDNSequence
+			if rest, err := asn1.Unmarshal(gn.Bytes, &rdn); err != nil || len(rest) > 0 {
+				e.Err("SubjectAltName contains a directoryName that can't be decoded")
+			} else if len(rdn) == 0 {
+				e.Err("SubjectAltName contains an empty directoryName")
+			}
+		case certdata.GeneralNameRegisteredID:
+			var oid asn1.ObjectIdentifier
+			if _, err := asn1.UnmarshalWithParams(gn.FullBytes, &oid, "tag:8"); err != nil {
+				e.Err("SubjectAltName contains a registeredID that can't be decoded")
+			}
+		case certdata.GeneralNameX400, certdata.GeneralNameEDIParty:
+			e.Warning("SubjectAltName contains a %s", name)
+		}
+	}
+
 	return e
 }
+
+// emptySubject returns true if the subject is an empty sequence
+func emptySubject(d *certdata.Data) bool {
+	var rdn pkix.RDNSequence
+	if _, err := asn1.Unmarshal(d.Cert.RawSubject, &rdn); err != nil {
+		return len(d.Cert.Subject.Names) == 0
+	}
+	return len(rdn) == 0
+}
+
+// equalGeneralName compares two names, domain names are case insensitive
+func equalGeneralName(a, b asn1.RawValue) bool {
+	if a.Tag != b.Tag {
+		return false
+	}
+	if a.Tag == certdata.GeneralNameDNS {
+		return strings.EqualFold(string(a.Bytes), string(b.Bytes))
+	}
+	return bytes.Equal(a.Bytes, b.Bytes)
+}
+
+func containsType(types []int, tag int) bool {
+	for _, t := range types {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
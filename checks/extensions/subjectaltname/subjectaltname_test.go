@@ -0,0 +1,192 @@
+package subjectaltname
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// generalName returns a primitive GeneralName with the given tag
+func generalName(tag int, value string) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, Bytes: []byte(value)}
+}
+
+// otherName returns an otherName GeneralName, the value is wrapped in the
+// explicit tag as asn1.Marshal ignores the explicit tag of a RawValue
+func otherName(t *testing.T, id asn1.ObjectIdentifier, tag int, value string) asn1.RawValue {
+	v, err := asn1.Marshal(asn1.RawValue{Tag: tag, Bytes: []byte(value)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := asn1.MarshalWithParams(struct {
+		TypeID asn1.ObjectIdentifier
+		Value  asn1.RawValue
+	}{
+		TypeID: id,
+		Value:  asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: v},
+	}, "tag:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return asn1.RawValue{FullBytes: b}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Names          []asn1.RawValue
+		CertType       string
+		EmptySubject   bool
+		Critical       bool
+		ExpectedErrors []string
+	}{
+		{
+			Name:     "Valid: dNSName and iPAddress",
+			Names:    []asn1.RawValue{generalName(2, "www.example.com"), generalName(7, "\xc0\x00\x02\x01")},
+			CertType: "DV",
+		},
+		{
+			Name:     "Invalid: unexpected types in TLS certificate",
+			Names:    []asn1.RawValue{generalName(2, "www.example.com"), generalName(1, "john@example.com"), generalName(6, "https://www.example.com/")},
+			CertType: "OV",
+			ExpectedErrors: []string{
+				"SubjectAltName contains a rfc822Name, which is not allowed in OV certificates",
+				"SubjectAltName contains a uniformResourceIdentifier, which is not allowed in OV certificates",
+			},
+		},
+		{
+			Name:     "Invalid: duplicate and empty names",
+			Names:    []asn1.RawValue{generalName(2, "www.example.com"), generalName(2, "WWW.example.com"), generalName(2, "")},
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"SubjectAltName contains a duplicate dNSName",
+				"SubjectAltName contains an empty dNSName",
+			},
+		},
+		{
+			Name:     "Invalid: empty sequence",
+			CertType: "DV",
+			ExpectedErrors: []string{
+				"SubjectAltName extension contains an empty sequence (RFC 5280 4.2.1.6)",
+			},
+		},
+		{
+			Name:         "Invalid: not critical with empty subject",
+			Names:        []asn1.RawValue{generalName(2, "www.example.com")},
+			CertType:     "DV",
+			EmptySubject: true,
+			ExpectedErrors: []string{
+				"SubjectAltName extension MUST be critical when the subject is empty (RFC 5280 4.2.1.6)",
+			},
+		},
+		{
+			Name:         "Valid: critical with empty subject",
+			Names:        []asn1.RawValue{generalName(2, "www.example.com")},
+			CertType:     "DV",
+			EmptySubject: true,
+			Critical:     true,
+		},
+		{
+			Name:     "Invalid: critical with subject",
+			Names:    []asn1.RawValue{generalName(2, "www.example.com")},
+			CertType: "DV",
+			Critical: true,
+			ExpectedErrors: []string{
+				"SubjectAltName extension set critical",
+			},
+		},
+		{
+			Name: "Valid: otherNames",
+			Names: []asn1.RawValue{
+				generalName(1, "john@example.com"),
+				otherName(t, idOnUPN, asn1.TagUTF8String, "john@example.com"),
+				otherName(t, idOnSmtpUTF8Mailbox, asn1.TagUTF8String, "jöhn@example.com"),
+				otherName(t, idOnXMPPAddr, asn1.TagUTF8String, "john@example.com/phone"),
+				otherName(t, idOnDNSSRV, asn1.TagIA5String, "_xmpp.example.com"),
+			},
+			CertType: "PS",
+		},
+		{
+			Name: "Invalid: otherNames",
+			Names: []asn1.RawValue{
+				otherName(t, idOnUPN, asn1.TagUTF8String, "john"),
+				otherName(t, idOnSmtpUTF8Mailbox, asn1.TagIA5String, "john@example.com"),
+				otherName(t, idOnXMPPAddr, asn1.TagUTF8String, "@example.com"),
+				otherName(t, idOnDNSSRV, asn1.TagIA5String, "xmpp.example.com"),
+				otherName(t, asn1.ObjectIdentifier{1, 2, 3}, asn1.TagUTF8String, "unknown"),
+				generalName(2, "www.example.com"),
+			},
+			CertType: "PS",
+			ExpectedErrors: []string{
+				"SubjectAltName otherName UPN 'john' is not formatted as user@domain",
+				"SubjectAltName otherName XmppAddr '@example.com' is not a valid JID (RFC 6120 13.7.1.4)",
+				"SubjectAltName otherName SRVName 'xmpp.example.com' is not formatted as _Service.Name (RFC 4985 2)",
+				"SubjectAltName contains an unknown otherName 1.2.3",
+				"SubjectAltName contains a dNSName, which is not expected in PS certificates",
+			},
+		},
+		{
+			// The mailboxes of PS certificates are validated by the certificate
+			// SubjectAltName check
+			Name: "Valid: PS mailboxes are not validated twice",
+			Names: []asn1.RawValue{
+				generalName(1, "jöhn@example.com"),
+				otherName(t, idOnSmtpUTF8Mailbox, asn1.TagIA5String, "john@example.com"),
+			},
+			CertType: "PS",
+		},
+		{
+			Name: "Invalid: mailboxes",
+			Names: []asn1.RawValue{
+				generalName(1, "jöhn@example.com"),
+				otherName(t, idOnSmtpUTF8Mailbox, asn1.TagIA5String, "john@example.com"),
+			},
+			CertType: "CS",
+			ExpectedErrors: []string{
+				"SubjectAltName rfc822Name contains non-ASCII characters",
+				"SubjectAltName otherName SmtpUTF8Mailbox is not an UTF8String",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := asn1.Marshal(tc.Names)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.Names == nil {
+				b = []byte{0x30, 0x00}
+			}
+
+			subject := pkix.Name{CommonName: "www.example.com"}
+			if tc.EmptySubject {
+				subject = pkix.Name{}
+			}
+			rawSubject, err := asn1.Marshal(subject.ToRDNSequence())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := &certdata.Data{
+				Cert: &x509.Certificate{RawSubject: rawSubject},
+				Type: tc.CertType,
+			}
+
+			errList := Check(pkix.Extension{Id: extensionOid, Critical: tc.Critical, Value: b}, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package subjectkeyid
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 
@@ -18,12 +22,87 @@ func init() {
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.1.2
+// https://tools.ietf.org/html/rfc7093
+//
 func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// RFC: Conforming CAs MUST mark this extension as non-critical.
 	if ex.Critical {
 		e.Err("SubjectKeyId extension set critical")
 	}
 
+	var ski []byte
+	if rest, err := asn1.Unmarshal(ex.Value, &ski); err != nil {
+		e.Err("SubjectKeyId extension can't be decoded: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("SubjectKeyId extension contains trailing data")
+	}
+
+	if len(ski) == 0 {
+		e.Err("SubjectKeyId extension contains an empty keyIdentifier (RFC 5280 4.2.1.2)")
+		return e
+	}
+
+	spki, err := certdata.ParseSubjectPublicKeyInfo(d.Cert.RawSubjectPublicKeyInfo)
+	if err != nil {
+		// public key errors are reported by the public key checks
+		return e
+	}
+
+	if !knownMethod(ski, d.Cert.RawSubjectPublicKeyInfo, spki.SubjectPublicKey.Bytes) {
+		e.Notice("SubjectKeyId is not derived from the public key using a method described in RFC 5280 4.2.1.2 or RFC 7093")
+	}
+
 	return e
 }
+
+// knownMethod returns true if the key identifier matches one of the
+// derivations of the public key as defined in RFC 5280 4.2.1.2 and RFC 7093.
+func knownMethod(ski, rawSPKI, publicKey []byte) bool {
+	for _, kid := range keyIdentifiers(rawSPKI, publicKey) {
+		if bytes.Equal(ski, kid) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyIdentifiers returns all key identifiers that can be derived from the
+// given SubjectPublicKeyInfo and the value of its subjectPublicKey BIT STRING.
+func keyIdentifiers(rawSPKI, publicKey []byte) [][]byte {
+	sum1 := sha1.Sum(publicKey)
+	sum256 := sha256.Sum256(publicKey)
+	sum384 := sha512.Sum384(publicKey)
+	sum512 := sha512.Sum512(publicKey)
+
+	// RFC 5280 4.2.1.2 (2): four-bit type field with the value 0100 followed
+	// by the least significant 60 bits of the SHA-1 hash.
+	method2 := make([]byte, 8)
+	copy(method2, sum1[12:])
+	method2[0] = 0x40 | (method2[0] & 0x0f)
+
+	spki1 := sha1.Sum(rawSPKI)
+	spki256 := sha256.Sum256(rawSPKI)
+	spki384 := sha512.Sum384(rawSPKI)
+	spki512 := sha512.Sum512(rawSPKI)
+
+	return [][]byte{
+		// RFC 5280 4.2.1.2 (1): SHA-1 of the subjectPublicKey
+		sum1[:],
+		method2,
+		// RFC 7093 (1-3): leftmost 160 bits of the SHA-256, SHA-384 or
+		// SHA-512 hash of the subjectPublicKey
+		sum256[:20],
+		sum384[:20],
+		sum512[:20],
+		// RFC 7093 (4): hash of the DER encoded SubjectPublicKeyInfo
+		spki1[:],
+		spki256[:],
+		spki384[:],
+		spki512[:],
+	}
+}
@@ -0,0 +1,122 @@
+package subjectkeyid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestCheck(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawSPKI, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := certdata.ParseSubjectPublicKeyInfo(rawSPKI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := spki.SubjectPublicKey.Bytes
+
+	sum1 := sha1.Sum(publicKey)
+	sum256 := sha256.Sum256(publicKey)
+	sum384 := sha512.Sum384(publicKey)
+	sum512 := sha512.Sum512(publicKey)
+	spki256 := sha256.Sum256(rawSPKI)
+
+	// RFC 5280 4.2.1.2 (2): 0100 followed by the least significant 60 bits
+	method2 := append([]byte(nil), sum1[12:]...)
+	method2[0] = 0x40 | (method2[0] & 0x0f)
+
+	extension := func(ski []byte, critical bool) pkix.Extension {
+		b, err := asn1.Marshal(ski)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkix.Extension{Id: extensionOid, Critical: critical, Value: b}
+	}
+
+	testCases := []struct {
+		Name           string
+		InputEx        pkix.Extension
+		ExpectedErrors []string
+	}{
+		{
+			Name:    "Valid: RFC 5280 method 1",
+			InputEx: extension(sum1[:], false),
+		},
+		{
+			Name:    "Valid: RFC 5280 method 2",
+			InputEx: extension(method2, false),
+		},
+		{
+			Name:    "Valid: RFC 7093 method 1",
+			InputEx: extension(sum256[:20], false),
+		},
+		{
+			Name:    "Valid: RFC 7093 method 2",
+			InputEx: extension(sum384[:20], false),
+		},
+		{
+			Name:    "Valid: RFC 7093 method 3",
+			InputEx: extension(sum512[:20], false),
+		},
+		{
+			Name:    "Valid: RFC 7093 method 4",
+			InputEx: extension(spki256[:], false),
+		},
+		{
+			Name:    "Invalid: unknown method",
+			InputEx: extension([]byte{1, 2, 3, 4}, false),
+			ExpectedErrors: []string{
+				"SubjectKeyId is not derived from the public key using a method described in RFC 5280 4.2.1.2 or RFC 7093",
+			},
+		},
+		{
+			Name:    "Invalid: critical extension",
+			InputEx: extension(sum1[:], true),
+			ExpectedErrors: []string{
+				"SubjectKeyId extension set critical",
+			},
+		},
+		{
+			Name:    "Invalid: empty keyIdentifier",
+			InputEx: extension([]byte{}, false),
+			ExpectedErrors: []string{
+				"SubjectKeyId extension contains an empty keyIdentifier (RFC 5280 4.2.1.2)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{RawSubjectPublicKeyInfo: rawSPKI},
+			}
+
+			errList := Check(tc.InputEx, d).List()
+			if len(tc.ExpectedErrors) != len(errList) {
+				t.Fatalf("wrong number of Check errors: expected %d, got %d (%v)",
+					len(tc.ExpectedErrors), len(errList), errList)
+			}
+			for i, err := range errList {
+				if errMsg := err.Error(); errMsg != tc.ExpectedErrors[i] {
+					t.Errorf("expected error %q at index %d, got %q",
+						tc.ExpectedErrors[i], i, errMsg)
+				}
+			}
+		})
+	}
+}
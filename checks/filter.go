@@ -1,18 +1,47 @@
 package checks
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
 	"time"
 
 	"github.com/globalsign/certlint/certdata"
 )
 
-// Filter defines condition on when a check is performed or not
+// CT precertificate poison extension (RFC 6962 3.1)
+var precertificatePoison = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
+// Filter defines condition on when a check is performed or not, all conditions
+// that are set must be met.
 type Filter struct {
 	Type          []string
 	IssuedBefore  *time.Time
 	IssuedAfter   *time.Time
 	ExpiresBefore *time.Time
 	ExpiresAfter  *time.Time
+
+	// Public key algorithm of the certificate
+	KeyAlgorithm []x509.PublicKeyAlgorithm
+
+	// Issuer Distinguished Name (as returned by pkix.Name.String) or the
+	// authority key identifier of the issuing CA
+	IssuerDN    []string
+	IssuerKeyID [][]byte
+
+	// Extensions that must all be present in the certificate
+	Extension []asn1.ObjectIdentifier
+
+	// Certificate is a CA, self-signed or a CT precertificate
+	CA             *bool
+	SelfSigned     *bool
+	Precertificate *bool
+
+	// Composition of filters, Not must not match, All must all match and at
+	// least one of Any must match.
+	Not *Filter
+	All []*Filter
+	Any []*Filter
 }
 
 // Check returns true if a certificate complies with the given filter
@@ -31,21 +60,99 @@ func (f *Filter) Check(d *certdata.Data) bool {
 	}
 
 	// Issued before given date
-	if f.IssuedBefore != nil && !d.Cert.NotBefore.Before(*f.IssuedBefore) {
+	if f.IssuedBefore != nil && !d.IssuanceDate().Before(*f.IssuedBefore) {
 		return false
 	}
 	// Issued after given date
-	if f.IssuedAfter != nil && !d.Cert.NotBefore.After(*f.IssuedAfter) {
+	if f.IssuedAfter != nil && !d.IssuanceDate().After(*f.IssuedAfter) {
 		return false
 	}
 	// Expires before given date
-	if f.ExpiresBefore != nil && !d.Cert.NotBefore.Before(*f.ExpiresBefore) {
+	if f.ExpiresBefore != nil && !d.ExpirationDate().Before(*f.ExpiresBefore) {
 		return false
 	}
 	// Expires after given date
-	if f.ExpiresAfter != nil && !d.Cert.NotAfter.After(*f.ExpiresAfter) {
+	if f.ExpiresAfter != nil && !d.ExpirationDate().After(*f.ExpiresAfter) {
+		return false
+	}
+
+	// Uses one of the given public key algorithms
+	if len(f.KeyAlgorithm) > 0 {
+		var inFilter bool
+		for _, a := range f.KeyAlgorithm {
+			if d.Cert.PublicKeyAlgorithm == a {
+				inFilter = true
+			}
+		}
+		if !inFilter {
+			return false
+		}
+	}
+
+	// Issued by one of the given CAs
+	if len(f.IssuerDN) > 0 {
+		var inFilter bool
+		for _, dn := range f.IssuerDN {
+			if d.Cert.Issuer.String() == dn {
+				inFilter = true
+			}
+		}
+		if !inFilter {
+			return false
+		}
+	}
+	if len(f.IssuerKeyID) > 0 {
+		var inFilter bool
+		for _, id := range f.IssuerKeyID {
+			if bytes.Equal(d.Cert.AuthorityKeyId, id) {
+				inFilter = true
+			}
+		}
+		if !inFilter {
+			return false
+		}
+	}
+
+	// Contains all given extensions
+	for _, oid := range f.Extension {
+		if _, ok := d.Extension(oid); !ok {
+			return false
+		}
+	}
+
+	if f.CA != nil && d.Cert.IsCA != *f.CA {
+		return false
+	}
+	if f.SelfSigned != nil && d.SelfSigned() != *f.SelfSigned {
 		return false
 	}
+	if f.Precertificate != nil {
+		if _, ok := d.Extension(precertificatePoison); ok != *f.Precertificate {
+			return false
+		}
+	}
+
+	// Composition of filters
+	if f.Not != nil && f.Not.Check(d) {
+		return false
+	}
+	for _, a := range f.All {
+		if !a.Check(d) {
+			return false
+		}
+	}
+	if len(f.Any) > 0 {
+		var inFilter bool
+		for _, a := range f.Any {
+			if a.Check(d) {
+				inFilter = true
+				break
+			}
+		}
+		if !inFilter {
+			return false
+		}
+	}
 
 	return true
 }
@@ -0,0 +1,129 @@
+package checks
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func boolean(b bool) *bool {
+	return &b
+}
+
+// selfSigned returns a self-signed ECDSA certificate
+func selfSigned(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestFilterCheck(t *testing.T) {
+	leaf := &certdata.Data{
+		Type: "DV",
+		Cert: &x509.Certificate{
+			NotBefore:          time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:           time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+			PublicKeyAlgorithm: x509.RSA,
+			Issuer:             pkix.Name{CommonName: "Issuing CA", Organization: []string{"Example"}},
+			AuthorityKeyId:     []byte{1, 2, 3},
+			Extensions:         []pkix.Extension{{Id: precertificatePoison, Critical: true, Value: []byte{0x05, 0x00}}},
+		},
+	}
+	root := &certdata.Data{Type: "CA", Cert: selfSigned(t)}
+
+	testCases := []struct {
+		Name     string
+		Filter   Filter
+		Data     *certdata.Data
+		Expected bool
+	}{
+		{"Empty filter", Filter{}, leaf, true},
+		{"Type", Filter{Type: []string{"OV", "DV"}}, leaf, true},
+		{"Other type", Filter{Type: []string{"EV"}}, leaf, false},
+		{"Issued before", Filter{IssuedBefore: date(2018, 7, 1)}, leaf, true},
+		{"Issued after", Filter{IssuedAfter: date(2018, 7, 1)}, leaf, false},
+		{"Expires before", Filter{ExpiresBefore: date(2019, 1, 1)}, leaf, false},
+		{"Expires before notAfter", Filter{ExpiresBefore: date(2019, 7, 1)}, leaf, true},
+		{"Expires after", Filter{ExpiresAfter: date(2019, 1, 1)}, leaf, true},
+		{"Key algorithm", Filter{KeyAlgorithm: []x509.PublicKeyAlgorithm{x509.ECDSA}}, leaf, false},
+		{"Key algorithm of root", Filter{KeyAlgorithm: []x509.PublicKeyAlgorithm{x509.ECDSA}}, root, true},
+		{"Issuer DN", Filter{IssuerDN: []string{"CN=Issuing CA,O=Example"}}, leaf, true},
+		{"Other issuer DN", Filter{IssuerDN: []string{"CN=Other CA"}}, leaf, false},
+		{"Issuer key identifier", Filter{IssuerKeyID: [][]byte{{1, 2, 3}}}, leaf, true},
+		{"Extension", Filter{Extension: []asn1.ObjectIdentifier{precertificatePoison}}, leaf, true},
+		{"Missing extension", Filter{Extension: []asn1.ObjectIdentifier{precertificatePoison}}, root, false},
+		{"CA", Filter{CA: boolean(true)}, root, true},
+		{"Not CA", Filter{CA: boolean(false)}, root, false},
+		{"Self-signed", Filter{SelfSigned: boolean(true)}, root, true},
+		{"Not self-signed", Filter{SelfSigned: boolean(true)}, leaf, false},
+		{"Precertificate", Filter{Precertificate: boolean(true)}, leaf, true},
+		{"Not a precertificate", Filter{Precertificate: boolean(false)}, leaf, false},
+		{"Negation", Filter{Not: &Filter{Type: []string{"CA"}}}, leaf, true},
+		{"Negation of root", Filter{Not: &Filter{Type: []string{"CA"}}}, root, false},
+		{"All", Filter{All: []*Filter{{Type: []string{"DV"}}, {IssuedAfter: date(2018, 1, 1)}}}, leaf, true},
+		{"Not all", Filter{All: []*Filter{{Type: []string{"DV"}}, {IssuedAfter: date(2019, 1, 1)}}}, leaf, false},
+		{"Any", Filter{Any: []*Filter{{Type: []string{"EV"}}, {Precertificate: boolean(true)}}}, leaf, true},
+		{"Not any", Filter{Any: []*Filter{{Type: []string{"EV"}}, {CA: boolean(true)}}}, leaf, false},
+		{"As of another date", Filter{IssuedAfter: date(2020, 1, 1)}, &certdata.Data{Type: "DV", Cert: leaf.Cert, AsOf: *date(2021, 1, 1)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := tc.Filter.Check(tc.Data); got != tc.Expected {
+				t.Errorf("expected %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestExtensionFilter(t *testing.T) {
+	oid := asn1.ObjectIdentifier{1, 2, 3}
+	f := func(pkix.Extension, *certdata.Data) *errors.Errors {
+		e := errors.New(nil)
+		e.Err("checked")
+		return e
+	}
+	ex := extensions{{"test", oid, &Filter{Type: []string{"EV"}}, f}}
+
+	d := &certdata.Data{Type: "EV", Cert: &x509.Certificate{}}
+	if l := ex.Check(pkix.Extension{Id: oid}, d).List(); len(l) != 1 {
+		t.Errorf("expected the check to run for a matching filter, got %v", l)
+	}
+
+	d.Type = "DV"
+	if l := ex.Check(pkix.Extension{Id: oid}, d).List(); len(l) != 0 {
+		t.Errorf("expected the check to be skipped, got %v", l)
+	}
+}
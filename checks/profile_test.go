@@ -0,0 +1,138 @@
+package checks
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/errors"
+)
+
+func TestSetProfile(t *testing.T) {
+	publicTLS := &certdata.Profile{Name: "Public TLS", IssuerKeyID: [][]byte{{1, 2, 3}}}
+	device := &certdata.Profile{Name: "Device", IssuerDN: []string{"CN=Device CA,O=Example"}, Type: "DEVICE"}
+	certdata.SetProfiles([]*certdata.Profile{publicTLS, device})
+	defer certdata.SetProfiles(nil)
+
+	var testData = []struct {
+		Data            *certdata.Data
+		ExpectedProfile *certdata.Profile
+		ExpectedType    string
+	}{
+		{
+			// Authority key identifier of the certificate
+			Data:            &certdata.Data{Type: "DV", Cert: &x509.Certificate{AuthorityKeyId: []byte{1, 2, 3}}},
+			ExpectedProfile: publicTLS,
+			ExpectedType:    "DV",
+		},
+		{
+			// Subject key identifier of the issuer
+			Data: &certdata.Data{
+				Type:   "DV",
+				Cert:   &x509.Certificate{AuthorityKeyId: []byte{4, 5, 6}},
+				Issuer: &x509.Certificate{SubjectKeyId: []byte{1, 2, 3}},
+			},
+			ExpectedProfile: publicTLS,
+			ExpectedType:    "DV",
+		},
+		{
+			// Issuer DN with a type override
+			Data: &certdata.Data{
+				Type: "OV",
+				Cert: &x509.Certificate{Issuer: pkix.Name{CommonName: "Device CA", Organization: []string{"Example"}}},
+			},
+			ExpectedProfile: device,
+			ExpectedType:    "DEVICE",
+		},
+		{
+			Data: &certdata.Data{
+				Type: "OV",
+				Cert: &x509.Certificate{AuthorityKeyId: []byte{4, 5, 6}, Issuer: pkix.Name{CommonName: "Other CA"}},
+			},
+			ExpectedProfile: nil,
+			ExpectedType:    "OV",
+		},
+	}
+
+	for i, td := range testData {
+		if ok := td.Data.SetProfile(); ok != (td.ExpectedProfile != nil) {
+			t.Errorf("Test %d: unexpected match got %t", i, ok)
+		}
+		if td.Data.Profile != td.ExpectedProfile {
+			t.Errorf("Test %d: unexpected profile got %v, want %v", i, td.Data.Profile, td.ExpectedProfile)
+		}
+		if td.Data.Type != td.ExpectedType {
+			t.Errorf("Test %d: unexpected type got %s, want %s", i, td.Data.Type, td.ExpectedType)
+		}
+	}
+}
+
+type expectedError struct {
+	priority errors.Priority
+	msg      string
+}
+
+func TestProfileCheck(t *testing.T) {
+	c := certificate{
+		{"Error Check", nil, func(d *certdata.Data) *errors.Errors {
+			var e = errors.New(nil)
+			e.Err("Error")
+			return e
+		}},
+		{"Warning Check", nil, func(d *certdata.Data) *errors.Errors {
+			var e = errors.New(nil)
+			e.Warning("Warning")
+			return e
+		}},
+		{"Nil Check", nil, func(d *certdata.Data) *errors.Errors {
+			return nil
+		}},
+	}
+
+	var testData = []struct {
+		Profile        *certdata.Profile
+		ExpectedErrors []expectedError
+	}{
+		{
+			Profile: nil,
+			ExpectedErrors: []expectedError{
+				{errors.Error, "Error"},
+				{errors.Warning, "Warning"},
+			},
+		},
+		{
+			Profile: &certdata.Profile{Disabled: []string{"Error Check"}},
+			ExpectedErrors: []expectedError{
+				{errors.Warning, "Warning"},
+			},
+		},
+		{
+			Profile: &certdata.Profile{Checks: []string{"Error Check", "Nil Check"}},
+			ExpectedErrors: []expectedError{
+				{errors.Error, "Error"},
+			},
+		},
+		{
+			Profile: &certdata.Profile{Priorities: map[string]errors.Priority{"Error Check": errors.Notice, "Nil Check": errors.Alert}},
+			ExpectedErrors: []expectedError{
+				{errors.Notice, "Error"},
+				{errors.Warning, "Warning"},
+			},
+		},
+	}
+
+	for i, td := range testData {
+		d := &certdata.Data{Type: "DV", Cert: &x509.Certificate{}, Profile: td.Profile}
+		e := c.Check(d)
+		if len(e.List()) != len(td.ExpectedErrors) {
+			t.Errorf("Test %d: unexpected errors got %v, want %v", i, e.List(), td.ExpectedErrors)
+			continue
+		}
+		for j, err := range e.List() {
+			if err.Priority() != td.ExpectedErrors[j].priority || err.Error() != td.ExpectedErrors[j].msg {
+				t.Errorf("Test %d: unexpected error got %s %s, want %s %s", i, err.Priority(), err.Error(), td.ExpectedErrors[j].priority, td.ExpectedErrors[j].msg)
+			}
+		}
+	}
+}
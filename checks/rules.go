@@ -0,0 +1,61 @@
+package checks
+
+import (
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+// Rule is a version of a requirement that applies to certificates issued on
+// or after the effective date and before the sunset date, a zero date leaves
+// that end of the period open. Value contains the parameter of the rule, e.g.
+// the maximum validity period.
+type Rule struct {
+	Name      string
+	Effective time.Time
+	Sunset    time.Time
+	Value     interface{}
+}
+
+// Rules contains the versions of a single requirement
+type Rules []Rule
+
+// In returns true if the rule applies to certificates issued at t
+func (r Rule) In(t time.Time) bool {
+	if !r.Effective.IsZero() && t.Before(r.Effective) {
+		return false
+	}
+	if !r.Sunset.IsZero() && !t.Before(r.Sunset) {
+		return false
+	}
+	return true
+}
+
+// Applies returns true if the rule applies to the issuance date of the
+// certificate
+func (r Rule) Applies(d *certdata.Data) bool {
+	return r.In(d.IssuanceDate())
+}
+
+// At returns the version of the requirement that applies at t, if the periods
+// overlap the version with the latest effective date is returned.
+func (r Rules) At(t time.Time) (Rule, bool) {
+	var rule Rule
+	var found bool
+	for _, v := range r {
+		if !v.In(t) {
+			continue
+		}
+		if !found || v.Effective.After(rule.Effective) {
+			rule = v
+			found = true
+		}
+	}
+	return rule, found
+}
+
+// Applicable returns the version of the requirement that applies to the
+// issuance date of the certificate
+func (r Rules) Applicable(d *certdata.Data) (Rule, bool) {
+	return r.At(d.IssuanceDate())
+}
@@ -0,0 +1,53 @@
+package checks
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+)
+
+func TestRules(t *testing.T) {
+	rules := Rules{
+		{Name: "v1", Sunset: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), Value: 1},
+		{Name: "v2", Effective: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), Sunset: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), Value: 2},
+		{Name: "v3", Effective: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), Value: 3},
+		{Name: "v3 amendment", Effective: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Value: 4},
+	}
+
+	testCases := []struct {
+		Name      string
+		NotBefore time.Time
+		AsOf      time.Time
+		Expected  string
+	}{
+		{Name: "before the first effective date", NotBefore: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), Expected: "v1"},
+		{Name: "on the effective date", NotBefore: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), Expected: "v2"},
+		{Name: "one second before the sunset date", NotBefore: time.Date(2020, 8, 31, 23, 59, 59, 0, time.UTC), Expected: "v2"},
+		{Name: "on the sunset date", NotBefore: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), Expected: "v3"},
+		{Name: "latest effective date", NotBefore: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Expected: "v3 amendment"},
+		{Name: "as of another date", NotBefore: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), AsOf: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), Expected: "v2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &certdata.Data{
+				Cert: &x509.Certificate{NotBefore: tc.NotBefore},
+				AsOf: tc.AsOf,
+			}
+
+			rule, ok := rules.Applicable(d)
+			if !ok {
+				t.Fatalf("no rule applies, expected %s", tc.Expected)
+			}
+			if rule.Name != tc.Expected {
+				t.Errorf("expected rule %s, got %s", tc.Expected, rule.Name)
+			}
+		})
+	}
+
+	if _, ok := (Rules{{Name: "future", Effective: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}}).At(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("expected no rule before the effective date")
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/globalsign/certlint/certdata"
+	"github.com/globalsign/certlint/checks"
+	"github.com/globalsign/certlint/checks/certificate/publickey"
+	"github.com/globalsign/certlint/checks/certificate/publickey/goodkey"
+	"github.com/globalsign/certlint/checks/certificate/validity"
+	"github.com/globalsign/certlint/checks/certificate/wildcard"
+	"github.com/globalsign/certlint/errors"
+)
+
+// config contains the settings of the certlint configuration file, an example:
+//
+//  {
+//    "keyPolicy": {
+//      "minRSAKeySize": 3072,
+//      "allowECDSANISTP256": false
+//    },
+//    "typeKeyPolicy": {
+//      "PS": { "minRSAKeySize": 2048 }
+//    },
+//    "wildcardPrivateSuffixes": false,
+//    "backdateWindow": "24h",
+//    "profiles": [{
+//      "name": "Private TLS",
+//      "issuerKeyIds": ["a1b2c3..."],
+//      "issuerDNs": ["CN=Example Private CA,O=Example"],
+//      "disabled": ["Validity Check"],
+//      "priorities": { "Subject Check": "warning" },
+//      "type": "OV",
+//      "keyPolicy": { "minRSAKeySize": 2048 }
+//    }]
+//  }
+//
+// Omitted key policy settings use the defaults of goodkey.NewKeyPolicy, a
+// certificate type key policy extends the configured key policy.
+// wildcardPrivateSuffixes (default true) reports wildcards directly above a
+// suffix from the private section of the public suffix list.
+// backdateWindow (default 48h) is the maximum period that notBefore may precede
+// the earliest embedded SCT. This is a heuristic for the issuance date, it is
+// only evaluated for certificates with embedded SCTs.
+// profiles apply to the certificates of an issuer, identified by the subject
+// key identifier (hex) or the subject DN of the issuer, the first matching
+// profile is used. A profile limits the checks that are performed (all when
+// checks is omitted), overrides the priority of the findings of a check, the
+// certificate type and the key policy. The key policy of a profile extends the
+// configured key policy. Profile names must be unique and the checks are
+// identified by their registered name, e.g. "Validity Check", the ASN.1
+// linter findings by "ASN.1 Structure Check" and "ASN.1 Schema Check".
+type config struct {
+	KeyPolicy     json.RawMessage            `json:"keyPolicy"`
+	TypeKeyPolicy map[string]json.RawMessage `json:"typeKeyPolicy"`
+
+	WildcardPrivateSuffixes *bool  `json:"wildcardPrivateSuffixes"`
+	BackdateWindow          string `json:"backdateWindow"`
+
+	Profiles []profileConfig `json:"profiles"`
+}
+
+// profileConfig contains the settings of a profile
+type profileConfig struct {
+	Name         string            `json:"name"`
+	IssuerKeyIDs []string          `json:"issuerKeyIds"`
+	IssuerDNs    []string          `json:"issuerDNs"`
+	Checks       []string          `json:"checks"`
+	Disabled     []string          `json:"disabled"`
+	Priorities   map[string]string `json:"priorities"`
+	Type         string            `json:"type"`
+	KeyPolicy    json.RawMessage   `json:"keyPolicy"`
+}
+
+// loadConfig reads the configuration file and applies the settings
+func loadConfig(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var c config
+	if err = json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	kp := goodkey.NewKeyPolicy()
+	if len(c.KeyPolicy) > 0 {
+		if err = json.Unmarshal(c.KeyPolicy, &kp); err != nil {
+			return err
+		}
+	}
+	publickey.SetKeyPolicy(kp)
+
+	for certType, raw := range c.TypeKeyPolicy {
+		// copy the exponent list to prevent sharing it between policies
+		tkp := kp
+		tkp.RSAExponents = append([]int(nil), kp.RSAExponents...)
+		if err = json.Unmarshal(raw, &tkp); err != nil {
+			return err
+		}
+		publickey.SetTypeKeyPolicy(certType, tkp)
+	}
+
+	if c.WildcardPrivateSuffixes != nil {
+		wildcard.SetPrivateSuffixes(*c.WildcardPrivateSuffixes)
+	}
+
+	if len(c.BackdateWindow) > 0 {
+		window, err := time.ParseDuration(c.BackdateWindow)
+		if err != nil {
+			return err
+		}
+		validity.SetBackdateWindow(window)
+	}
+
+	var profiles []*certdata.Profile
+	names := make(map[string]bool)
+	for _, pc := range c.Profiles {
+		// the key policy of a profile is registered by name
+		if names[pc.Name] {
+			return fmt.Errorf("profile %s is defined more than once", pc.Name)
+		}
+		names[pc.Name] = true
+
+		p, err := pc.profile()
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, p)
+
+		if len(pc.KeyPolicy) > 0 {
+			pkp := kp
+			pkp.RSAExponents = append([]int(nil), kp.RSAExponents...)
+			if err = json.Unmarshal(pc.KeyPolicy, &pkp); err != nil {
+				return err
+			}
+			publickey.SetProfileKeyPolicy(pc.Name, pkp)
+		}
+	}
+	certdata.SetProfiles(profiles)
+
+	return nil
+}
+
+// profile converts the profile settings into a certdata.Profile
+func (pc profileConfig) profile() (*certdata.Profile, error) {
+	if len(pc.Name) == 0 {
+		return nil, fmt.Errorf("profile without a name")
+	}
+	if len(pc.IssuerKeyIDs) == 0 && len(pc.IssuerDNs) == 0 {
+		return nil, fmt.Errorf("profile %s does not identify an issuer", pc.Name)
+	}
+
+	p := &certdata.Profile{
+		Name:       pc.Name,
+		IssuerDN:   pc.IssuerDNs,
+		Checks:     pc.Checks,
+		Disabled:   pc.Disabled,
+		Priorities: make(map[string]errors.Priority),
+		Type:       pc.Type,
+	}
+	// a misspelled check name would silently enable or disable checks
+	for _, list := range [][]string{pc.Checks, pc.Disabled} {
+		for _, check := range list {
+			if !knownCheck(check) {
+				return nil, fmt.Errorf("profile %s contains an unknown check %s", pc.Name, check)
+			}
+		}
+	}
+	for check := range pc.Priorities {
+		if !knownCheck(check) {
+			return nil, fmt.Errorf("profile %s contains an unknown check %s", pc.Name, check)
+		}
+	}
+
+	for _, id := range pc.IssuerKeyIDs {
+		b, err := hex.DecodeString(strings.Replace(id, ":", "", -1))
+		if err != nil {
+			return nil, fmt.Errorf("profile %s contains an invalid issuer key id: %s", pc.Name, err.Error())
+		}
+		p.IssuerKeyID = append(p.IssuerKeyID, b)
+	}
+	for check, priority := range pc.Priorities {
+		pp, ok := priorityMap[strings.ToLower(priority)]
+		if !ok {
+			return nil, fmt.Errorf("profile %s contains an invalid priority %s", pc.Name, priority)
+		}
+		p.Priorities[check] = pp
+	}
+	return p, nil
+}
+
+// knownCheck returns true for a registered check or a check of the ASN.1
+// linter
+func knownCheck(name string) bool {
+	return name == asn1StructCheck || name == asn1SchemaCheck || checks.Registered(name)
+}
@@ -109,6 +109,22 @@ func (e *Errors) Append(err *Errors) error {
 	return nil
 }
 
+// SetPriority changes the priority of all errors to p
+func (e *Errors) SetPriority(p Priority) {
+	if e == nil {
+		return
+	}
+
+	e.m.Lock()
+	for i := range e.err {
+		e.err[i].p = p
+	}
+	if len(e.err) > 0 {
+		e.p = p
+	}
+	e.m.Unlock()
+}
+
 // Emerg log an error with severity Emergency
 func (e *Errors) Emerg(format string, a ...interface{}) error {
 	return e.add(Emergency, format, a...)
@@ -30,3 +30,22 @@ func TestErrors(t *testing.T) {
 		t.Errorf("Unexpected length got %d, want %d", len(e.List()), 3)
 	}
 }
+
+func TestSetPriority(t *testing.T) {
+	e := new(Errors)
+	e.Warning("Warning")
+	e.Crit("Critical")
+
+	e.SetPriority(Notice)
+	if e.Priority() != Notice {
+		t.Errorf("Unexpected priority got %d, want %d", e.Priority(), Notice)
+	}
+	for _, err := range e.List() {
+		if err.Priority() != Notice {
+			t.Errorf("Unexpected priority of '%s' got %d, want %d", err.Error(), err.Priority(), Notice)
+		}
+	}
+
+	var empty *Errors
+	empty.SetPriority(Notice)
+}
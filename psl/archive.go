@@ -0,0 +1,84 @@
+package psl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// snapshotLayout is the file name layout of a snapshot in an archive
+const snapshotLayout = "2006-01-02"
+
+// Archive is a directory of dated public suffix list snapshots, the file
+// name of a snapshot is the date the list was published followed by the .dat
+// extension (e.g. 2018-06-01.dat). Snapshots are loaded when first used.
+type Archive struct {
+	dir       string
+	snapshots []snapshot
+}
+
+type snapshot struct {
+	date time.Time
+	file string
+
+	once sync.Once
+	list *FileList
+	err  error
+}
+
+// LoadArchive reads the snapshots available in the directory
+func LoadArchive(dir string) (*Archive, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{dir: dir}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".dat" {
+			continue
+		}
+		date, err := time.Parse(snapshotLayout, strings.TrimSuffix(f.Name(), ".dat"))
+		if err != nil {
+			continue
+		}
+		a.snapshots = append(a.snapshots, snapshot{
+			date: date,
+			file: filepath.Join(dir, f.Name()),
+		})
+	}
+
+	if len(a.snapshots) == 0 {
+		return nil, fmt.Errorf("no public suffix list snapshots found in %s", dir)
+	}
+
+	sort.Slice(a.snapshots, func(i, j int) bool {
+		return a.snapshots[i].date.Before(a.snapshots[j].date)
+	})
+
+	return a, nil
+}
+
+// At returns the most recent snapshot published at or before the given time,
+// the oldest snapshot is returned when the time precedes all snapshots.
+func (a *Archive) At(t time.Time) (List, error) {
+	i := sort.Search(len(a.snapshots), func(i int) bool {
+		return a.snapshots[i].date.After(t)
+	})
+	if i > 0 {
+		i--
+	}
+
+	s := &a.snapshots[i]
+	s.once.Do(func() {
+		s.list, s.err = LoadFile(s.file)
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.list, nil
+}
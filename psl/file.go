@@ -0,0 +1,156 @@
+package psl
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Section markers and header fields of public_suffix_list.dat
+const (
+	beginICANN    = "// ===BEGIN ICANN DOMAINS==="
+	endICANN      = "// ===END ICANN DOMAINS==="
+	headerVersion = "// VERSION:"
+	headerCommit  = "// COMMIT:"
+)
+
+// FileList is a public suffix list parsed from the public_suffix_list.dat
+// format as published on https://publicsuffix.org/list/
+type FileList struct {
+	version    string
+	rules      map[string]bool
+	wildcards  map[string]bool
+	exceptions map[string]bool
+}
+
+// LoadFile reads a public suffix list file
+func LoadFile(filename string) (*FileList, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+	return l, nil
+}
+
+// Parse reads a list in the public_suffix_list.dat format. The version is
+// taken from the VERSION and COMMIT header of the list, lists without these
+// headers are identified by their SHA-256 hash.
+func Parse(r io.Reader) (*FileList, error) {
+	l := &FileList{
+		rules:      make(map[string]bool),
+		wildcards:  make(map[string]bool),
+		exceptions: make(map[string]bool),
+	}
+
+	var version, commit string
+	var icann bool
+	h := sha256.New()
+	scanner := bufio.NewScanner(io.TeeReader(r, h))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case len(line) == 0:
+			continue
+		case line == beginICANN:
+			icann = true
+			continue
+		case line == endICANN:
+			icann = false
+			continue
+		case strings.HasPrefix(line, headerVersion):
+			version = strings.TrimSpace(strings.TrimPrefix(line, headerVersion))
+			continue
+		case strings.HasPrefix(line, headerCommit):
+			commit = strings.TrimSpace(strings.TrimPrefix(line, headerCommit))
+			continue
+		case strings.HasPrefix(line, "//"):
+			continue
+		}
+
+		// Rules end at the first whitespace
+		rule := strings.Fields(line)[0]
+
+		m := l.rules
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			m, rule = l.exceptions, rule[1:]
+		case strings.HasPrefix(rule, "*."):
+			m, rule = l.wildcards, rule[2:]
+		}
+
+		ascii, err := idna.ToASCII(strings.ToLower(rule))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %s", line, err.Error())
+		}
+		m[ascii] = icann
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(l.rules)+len(l.wildcards) == 0 {
+		return nil, fmt.Errorf("public suffix list contains no rules")
+	}
+
+	switch {
+	case len(version) > 0 && len(commit) > 0:
+		l.version = fmt.Sprintf("%s (%s)", version, commit)
+	case len(version) > 0:
+		l.version = version
+	default:
+		l.version = "sha256:" + hex.EncodeToString(h.Sum(nil))
+	}
+
+	return l, nil
+}
+
+// PublicSuffix returns the public suffix of the domain following the
+// algorithm described on https://publicsuffix.org/list/
+func (l *FileList) PublicSuffix(domain string) (string, bool) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if len(domain) == 0 {
+		return "", false
+	}
+	labels := strings.Split(domain, ".")
+
+	// An exception rule takes priority over any other matching rule
+	for i := range labels[:len(labels)-1] {
+		if icann, ok := l.exceptions[strings.Join(labels[i:], ".")]; ok {
+			return strings.Join(labels[i+1:], "."), icann
+		}
+	}
+
+	// Otherwise the longest matching rule prevails
+	for i := range labels {
+		suffix := strings.Join(labels[i:], ".")
+		if icann, ok := l.rules[suffix]; ok {
+			return suffix, icann
+		}
+		if i+1 < len(labels) {
+			if icann, ok := l.wildcards[strings.Join(labels[i+1:], ".")]; ok {
+				return suffix, icann
+			}
+		}
+	}
+
+	// If no rules match, the prevailing rule is "*"
+	return labels[len(labels)-1], false
+}
+
+// Version returns the version of the list
+func (l *FileList) Version() string {
+	return l.version
+}
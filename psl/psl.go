@@ -0,0 +1,85 @@
+// Package psl provides the public suffix list used to evaluate domain names.
+//
+// By default the list compiled into golang.org/x/net/publicsuffix is used, a
+// public_suffix_list.dat file or an archive of dated snapshots can be loaded
+// to get reproducible results that do not depend on the library version.
+package psl
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// List is a public suffix list
+type List interface {
+	// PublicSuffix returns the public suffix of the domain and if the suffix
+	// is managed by the ICANN, domains without a matching rule return the
+	// last label.
+	PublicSuffix(domain string) (suffix string, icann bool)
+
+	// Version returns the version of the list
+	Version() string
+}
+
+// builtin is the list compiled into golang.org/x/net/publicsuffix
+type builtin struct{}
+
+func (builtin) PublicSuffix(domain string) (string, bool) {
+	return publicsuffix.PublicSuffix(domain)
+}
+
+func (builtin) Version() string {
+	return publicsuffix.List.String()
+}
+
+// Builtin returns the list compiled into golang.org/x/net/publicsuffix
+func Builtin() List {
+	return builtin{}
+}
+
+var (
+	mu      sync.RWMutex
+	current List = builtin{}
+	archive *Archive
+)
+
+// Default returns the list that is used when no list is given
+func Default() List {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetDefault replaces the default list, nil restores the builtin list
+func SetDefault(l List) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l == nil {
+		l = builtin{}
+	}
+	current = l
+}
+
+// SetArchive configures an archive of snapshots that is used to evaluate a
+// certificate against the list as of its notBefore date, nil disables the
+// archive.
+func SetArchive(a *Archive) {
+	mu.Lock()
+	defer mu.Unlock()
+	archive = a
+}
+
+// At returns the list as it was at the given time when an archive is
+// configured, otherwise the default list is returned.
+func At(t time.Time) (List, error) {
+	mu.RLock()
+	a, l := archive, current
+	mu.RUnlock()
+
+	if a == nil {
+		return l, nil
+	}
+	return a.At(t)
+}
@@ -0,0 +1,136 @@
+package psl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testList = `// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0.
+
+// VERSION: 2018-06-01_12-00-00_UTC
+// COMMIT: 0123abcd
+
+// ===BEGIN ICANN DOMAINS===
+
+com
+uk
+co.uk
+*.ck
+!www.ck
+// idn rule
+公司.cn
+cn
+
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+
+blogspot.com
+appspot.com  some comment
+
+// ===END PRIVATE DOMAINS===
+`
+
+func TestFileList(t *testing.T) {
+	l, err := Parse(strings.NewReader(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := l.Version(); v != "2018-06-01_12-00-00_UTC (0123abcd)" {
+		t.Errorf("unexpected version %q", v)
+	}
+
+	testCases := []struct {
+		Domain string
+		Suffix string
+		ICANN  bool
+	}{
+		{"www.example.com", "com", true},
+		{"WWW.Example.COM.", "com", true},
+		{"com", "com", true},
+		{"www.example.co.uk", "co.uk", true},
+		{"example.uk", "uk", true},
+		{"www.example.ck", "example.ck", true},
+		{"www.ck", "ck", true},
+		{"xn--55qx5d.cn", "xn--55qx5d.cn", true},
+		{"example.xn--55qx5d.cn", "xn--55qx5d.cn", true},
+		{"example.blogspot.com", "blogspot.com", false},
+		{"example.appspot.com", "appspot.com", false},
+		{"example.internal", "internal", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		suffix, icann := l.PublicSuffix(tc.Domain)
+		if suffix != tc.Suffix || icann != tc.ICANN {
+			t.Errorf("%q: expected %q/%t, got %q/%t", tc.Domain, tc.Suffix, tc.ICANN, suffix, icann)
+		}
+	}
+}
+
+func TestFileListVersionHash(t *testing.T) {
+	l, err := Parse(strings.NewReader("com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(l.Version(), "sha256:") {
+		t.Errorf("unexpected version %q", l.Version())
+	}
+
+	if _, err := Parse(strings.NewReader("// no rules\n")); err == nil {
+		t.Error("expected an error for a list without rules")
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "psl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"2017-01-01.dat": "// VERSION: 2017\ncom\n",
+		"2018-01-01.dat": "// VERSION: 2018\ncom\nexample.com\n",
+		"README":         "ignored",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, err := LoadArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		At      time.Time
+		Version string
+		Suffix  string
+	}{
+		{time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC), "2017", "com"},
+		{time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC), "2017", "com"},
+		{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "2018", "example.com"},
+		{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "2018", "example.com"},
+	}
+
+	for _, tc := range testCases {
+		l, err := a.At(tc.At)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Version() != tc.Version {
+			t.Errorf("%s: expected version %q, got %q", tc.At, tc.Version, l.Version())
+		}
+		if suffix, _ := l.PublicSuffix("www.example.com"); suffix != tc.Suffix {
+			t.Errorf("%s: expected suffix %q, got %q", tc.At, tc.Suffix, suffix)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/globalsign/certlint/checks/certificate/publickey/goodkey"
+	"github.com/globalsign/certlint/errors"
+)
+
+// sharedKey identifies a certificate of which the RSA key shares a prime
+// factor with the key of another certificate, only the fields of the report
+// are kept to limit the memory used in bulk mode.
+type sharedKey struct {
+	Issuer      string
+	Serial      string
+	Fingerprint [sha256.Size]byte
+}
+
+// moduli collects the RSA moduli of all certificates in bulk mode to find
+// shared prime factors when all certificates are processed.
+var moduli = struct {
+	sync.Mutex
+	index  map[[sha256.Size]byte]int
+	values []*big.Int
+	certs  [][]sharedKey
+}{index: make(map[[sha256.Size]byte]int)}
+
+// addModulus saves the RSA modulus of the certificate in the result
+func addModulus(r testResult) {
+	key, ok := r.Cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return
+	}
+
+	// certificates for the same key are grouped, a reused key is not a shared
+	// factor
+	moduli.Lock()
+	defer moduli.Unlock()
+	k := sha256.Sum256(key.N.Bytes())
+	i, ok := moduli.index[k]
+	if !ok {
+		i = len(moduli.values)
+		moduli.index[k] = i
+		moduli.values = append(moduli.values, key.N)
+		moduli.certs = append(moduli.certs, nil)
+	}
+	moduli.certs[i] = append(moduli.certs[i], sharedKey{
+		Issuer:      fmt.Sprintf("%s, %s", r.Cert.Issuer.CommonName, r.Cert.Issuer.Organization),
+		Serial:      hex.EncodeToString(r.Cert.SerialNumber.Bytes()),
+		Fingerprint: sha256.Sum256(r.Der),
+	})
+}
+
+// checkSharedFactors runs a batch GCD on all collected moduli and queues a
+// dedicated result for every certificate of which the key shares a prime
+// factor with another certificate.
+func checkSharedFactors() {
+	moduli.Lock()
+	defer moduli.Unlock()
+
+	one := big.NewInt(1)
+	for i, gcd := range goodkey.BatchGCD(moduli.values) {
+		if gcd.Cmp(one) == 0 {
+			continue
+		}
+		for j := range moduli.certs[i] {
+			r := testResult{
+				Shared: &moduli.certs[i][j],
+				Errors: errors.New(nil),
+			}
+			r.Errors.Err("Certificate key shares a prime factor with another certificate")
+			results <- r
+		}
+	}
+}